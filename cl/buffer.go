@@ -0,0 +1,148 @@
+package cl
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// ErrBufferOutOfRange is returned when an element range does not fit in a Buffer.
+var ErrBufferOutOfRange = errors.New("cl: Buffer element range out of range")
+
+// Number is the set of Go types that have a PrimitiveType.
+type Number interface {
+	int8 | uint8 | int16 | uint16 | int32 | uint32 | float32 | int64 | uint64 | float64 | uint | Half
+}
+
+// numTypeOf returns the PrimitiveType of T.
+func numTypeOf[T Number]() PrimitiveType {
+	var zero T
+	switch any(zero).(type) {
+	case int8:
+		return Int8
+	case uint8:
+		return Uint8
+	case int16:
+		return Int16
+	case uint16:
+		return Uint16
+	case int32:
+		return Int32
+	case uint32:
+		return Uint32
+	case float32:
+		return Float32
+	case int64:
+		return Int64
+	case uint64:
+		return Uint64
+	case float64:
+		return Float64
+	case Half:
+		return Float16
+	default:
+		return Uint
+	}
+}
+
+// Buffer is a buffer MemObject holding elements of type T. Offsets and
+// lengths of its methods are counted in elements, not bytes.
+type Buffer[T Number] struct {
+	memObject *MemObject
+	length    int
+}
+
+// NewBuffer creates a Buffer of length elements.
+func NewBuffer[T Number](ctx *Context, flags MemFlag, length int) (*Buffer[T], error) {
+	memObject, err := ctx.CreateEmptyBuffer(flags, length*int(numTypeOf[T]().SizeofT()))
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer[T]{memObject: memObject, length: length}, nil
+}
+
+// NewBufferFromSlice creates a Buffer the length of data. With MemCopyHostPtr
+// or MemUseHostPtr in flags the buffer is initialized from or backed by data.
+func NewBufferFromSlice[T Number](ctx *Context, flags MemFlag, data []T) (*Buffer[T], error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	memObject, err := ctx.CreateBufferUnsafe(flags, len(data)*int(numTypeOf[T]().SizeofT()), unsafe.Pointer(&data[0]))
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer[T]{memObject: memObject, length: len(data)}, nil
+}
+
+// MemObject is the underlying memory object.
+func (b *Buffer[T]) MemObject() *MemObject {
+	return b.memObject
+}
+
+// Release ..
+func (b *Buffer[T]) Release() {
+	b.memObject.Release()
+}
+
+// Len is the number of elements in the Buffer.
+func (b *Buffer[T]) Len() int {
+	return b.length
+}
+
+// NumType ..
+func (b *Buffer[T]) NumType() NumTyped {
+	return numTypeOf[T]()
+}
+
+// SizeofT ..
+func (b *Buffer[T]) SizeofT() uintptr {
+	return numTypeOf[T]().SizeofT()
+}
+
+// checkRange returns ErrBufferOutOfRange unless length elements starting at
+// offset are within the Buffer.
+func (b *Buffer[T]) checkRange(offset, length int) error {
+	if offset < 0 || length < 0 || offset+length > b.length {
+		return ErrBufferOutOfRange
+	}
+	return nil
+}
+
+// Write enqueues a command to write data to the Buffer starting at element offset.
+func (b *Buffer[T]) Write(q *CommandQueue, blocking bool, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	if err := b.checkRange(offset, len(data)); err != nil {
+		return nil, err
+	}
+	sizeofT := int(b.SizeofT())
+	return q.EnqueueWriteBuffer(b.memObject, blocking, offset*sizeofT, len(data)*sizeofT, unsafe.Pointer(&data[0]), eventWaitList)
+}
+
+// Read enqueues a command to read len(data) elements of the Buffer starting
+// at element offset into data.
+func (b *Buffer[T]) Read(q *CommandQueue, blocking bool, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	if err := b.checkRange(offset, len(data)); err != nil {
+		return nil, err
+	}
+	sizeofT := int(b.SizeofT())
+	return q.EnqueueReadBuffer(b.memObject, blocking, offset*sizeofT, len(data)*sizeofT, unsafe.Pointer(&data[0]), eventWaitList)
+}
+
+// SubBuffer creates a Buffer that is a view of length elements of the Buffer
+// starting at element offset. The byte offset must be aligned to the
+// MemBaseAddrAlign of the devices, see CreateSubBuffer.
+func (b *Buffer[T]) SubBuffer(flags MemFlag, offset, length int) (*Buffer[T], error) {
+	if err := b.checkRange(offset, length); err != nil {
+		return nil, err
+	}
+	sizeofT := int(b.SizeofT())
+	memObject, err := b.memObject.CreateSubBuffer(flags, offset*sizeofT, length*sizeofT)
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer[T]{memObject: memObject, length: length}, nil
+}
@@ -0,0 +1,8 @@
+// +build cl10
+
+package cl
+
+// Fill is not supported by OpenCL 1.0
+func (b *Buffer[T]) Fill(q *CommandQueue, value T, offset, length int, eventWaitList []*Event) (*Event, error) {
+	return nil, ErrUnsupported
+}
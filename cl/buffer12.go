@@ -0,0 +1,15 @@
+// +build !cl10
+
+package cl
+
+import "unsafe"
+
+// Fill enqueues a command to set length elements of the Buffer starting at
+// element offset to value.
+func (b *Buffer[T]) Fill(q *CommandQueue, value T, offset, length int, eventWaitList []*Event) (*Event, error) {
+	if err := b.checkRange(offset, length); err != nil {
+		return nil, err
+	}
+	sizeofT := int(b.SizeofT())
+	return q.EnqueueFillBuffer(b.memObject, unsafe.Pointer(&value), sizeofT, offset*sizeofT, length*sizeofT, eventWaitList)
+}
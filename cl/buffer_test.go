@@ -0,0 +1,85 @@
+package cl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumTypeOfBufferElements(t *testing.T) {
+	cases := []struct {
+		got, expected PrimitiveType
+	}{
+		{numTypeOf[int8](), Int8},
+		{numTypeOf[uint8](), Uint8},
+		{numTypeOf[int16](), Int16},
+		{numTypeOf[uint16](), Uint16},
+		{numTypeOf[int32](), Int32},
+		{numTypeOf[uint32](), Uint32},
+		{numTypeOf[float32](), Float32},
+		{numTypeOf[int64](), Int64},
+		{numTypeOf[uint64](), Uint64},
+		{numTypeOf[float64](), Float64},
+		{numTypeOf[uint](), Uint},
+	}
+	for _, c := range cases {
+		if c.got != c.expected {
+			t.Fatalf("numTypeOf expected %v got %v", c.expected, c.got)
+		}
+	}
+}
+
+func TestBufferChecksElementRange(t *testing.T) {
+	buffer := &Buffer[float32]{length: 4}
+	if _, err := buffer.Write(nil, true, 2, make([]float32, 3), nil); err != ErrBufferOutOfRange {
+		t.Fatalf("Write past the end expected ErrBufferOutOfRange got %v", err)
+	}
+	if _, err := buffer.Read(nil, true, -1, make([]float32, 1), nil); err != ErrBufferOutOfRange {
+		t.Fatalf("Read before the start expected ErrBufferOutOfRange got %v", err)
+	}
+	if _, err := buffer.SubBuffer(MemReadWrite, 4, 1); err != ErrBufferOutOfRange {
+		t.Fatalf("SubBuffer past the end expected ErrBufferOutOfRange got %v", err)
+	}
+}
+
+func TestBufferWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	input := []float32{1, 2, 3, 4}
+	buffer, err := NewBufferFromSlice(context, MemReadWrite|MemCopyHostPtr, input)
+	if err != nil {
+		t.Fatalf("NewBufferFromSlice error %v", err)
+	}
+	if buffer.Len() != 4 || buffer.NumType() != Float32 {
+		t.Fatalf("NewBufferFromSlice expected 4 Float32 got %d %v", buffer.Len(), buffer.NumType())
+	}
+	if _, err := buffer.Write(queue, true, 2, []float32{5}, nil); err != nil {
+		t.Fatalf("Write error %v", err)
+	}
+	output := make([]float32, 3)
+	if _, err := buffer.Read(queue, true, 1, output, nil); err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if expected := []float32{2, 5, 4}; !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Read expected %v got %v", expected, output)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, ""); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	kernel, err := program.CreateKernel("square")
+	if err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+	if err := kernel.SetArg(0, buffer); err != nil {
+		t.Fatalf("SetArg(Buffer) error %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package cl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiagnosticSeverity ..
+type DiagnosticSeverity int
+
+// DiagnosticSeverity variants
+const (
+	SeverityError DiagnosticSeverity = iota
+	SeverityWarning
+	SeverityNote
+)
+
+func (s DiagnosticSeverity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityNote:
+		return "note"
+	}
+	return fmt.Sprintf("Unknown(%d)", int(s))
+}
+
+// Diagnostic is a message of a compiler build log. File is the name the
+// compiler used for the source, often a placeholder like "<source>", and
+// Line and Column are 1-based, with Column 0 if the compiler gave none.
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity DiagnosticSeverity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	if d.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", d.File, d.Line, d.Severity, d.Message)
+}
+
+var (
+	// Clang based front ends (Intel, AMD ROCm, NVIDIA, Apple, POCL, Mesa):
+	//   <source>:3:5: error: use of undeclared identifier 'x'
+	clangDiagnostic = regexp.MustCompile(`^(.*?):(\d+):(\d+): (fatal error|error|warning|note|remark): (.*)$`)
+	// EDG based front ends (older AMD and NVIDIA):
+	//   "/tmp/OCL1234.cl", line 3: error: identifier "x" is undefined
+	edgDiagnostic = regexp.MustCompile(`^"(.*)", line (\d+): (catastrophic error|error|warning|remark)(?: #[\w-]+)?: (.*)$`)
+)
+
+func parseSeverity(s string) DiagnosticSeverity {
+	switch s {
+	case "warning":
+		return SeverityWarning
+	case "note", "remark":
+		return SeverityNote
+	default:
+		return SeverityError
+	}
+}
+
+// ParseBuildLog extracts the diagnostics from a build log in the formats of
+// the common OpenCL compiler front ends. Lines that are not diagnostics,
+// such as source excerpts and carets, are skipped.
+func ParseBuildLog(log string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := clangDiagnostic.FindStringSubmatch(line); m != nil {
+			lineNo, _ := strconv.Atoi(m[2])
+			column, _ := strconv.Atoi(m[3])
+			diagnostics = append(diagnostics, Diagnostic{File: m[1], Line: lineNo, Column: column, Severity: parseSeverity(m[4]), Message: m[5]})
+		} else if m := edgDiagnostic.FindStringSubmatch(line); m != nil {
+			lineNo, _ := strconv.Atoi(m[2])
+			diagnostics = append(diagnostics, Diagnostic{File: m[1], Line: lineNo, Severity: parseSeverity(m[3]), Message: m[4]})
+		}
+	}
+	return diagnostics
+}
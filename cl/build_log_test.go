@@ -0,0 +1,41 @@
+package cl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBuildLog(t *testing.T) {
+	log := "<source>:3:5: error: use of undeclared identifier 'x'\n" +
+		"    x = 1;\n" +
+		"    ^\n" +
+		"/tmp/kernel.cl:10:1: warning: unused variable 'y'\r\n" +
+		":4:2: note: previous definition is here\n" +
+		"\"/tmp/OCL1234.cl\", line 7: error: identifier \"z\" is undefined\n" +
+		"\"/tmp/OCL1234.cl\", line 8: warning #177-D: variable \"w\" was declared but never referenced\n" +
+		"1 error generated.\n"
+	expected := []Diagnostic{
+		{File: "<source>", Line: 3, Column: 5, Severity: SeverityError, Message: "use of undeclared identifier 'x'"},
+		{File: "/tmp/kernel.cl", Line: 10, Column: 1, Severity: SeverityWarning, Message: "unused variable 'y'"},
+		{File: "", Line: 4, Column: 2, Severity: SeverityNote, Message: "previous definition is here"},
+		{File: "/tmp/OCL1234.cl", Line: 7, Severity: SeverityError, Message: "identifier \"z\" is undefined"},
+		{File: "/tmp/OCL1234.cl", Line: 8, Severity: SeverityWarning, Message: "variable \"w\" was declared but never referenced"},
+	}
+	if diagnostics := ParseBuildLog(log); !reflect.DeepEqual(diagnostics, expected) {
+		t.Fatalf("ParseBuildLog expected %+v got %+v", expected, diagnostics)
+	}
+	if diagnostics := ParseBuildLog(""); diagnostics != nil {
+		t.Fatalf("ParseBuildLog of empty log expected nil got %+v", diagnostics)
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	d := Diagnostic{File: "<source>", Line: 3, Column: 5, Severity: SeverityError, Message: "oops"}
+	if s := d.String(); s != "<source>:3:5: error: oops" {
+		t.Fatalf("Diagnostic.String() got %q", s)
+	}
+	d.Column = 0
+	if s := d.String(); s != "<source>:3: error: oops" {
+		t.Fatalf("Diagnostic.String() without column got %q", s)
+	}
+}
@@ -0,0 +1,86 @@
+package cl
+
+/*
+#cgo CFLAGS: -I CL -w
+#cgo !darwin LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stddef.h>
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// OpenCL callbacks receive a void* user_data. Go pointers may not be handed
+// to C and kept there, so callbacks are stored in a registry and C only ever
+// sees an integer handle into it.
+var (
+	callbackMu     sync.Mutex
+	callbackNextID uintptr
+	callbackMap    = map[uintptr]interface{}{}
+)
+
+func registerCallback(cb interface{}) uintptr {
+	callbackMu.Lock()
+	defer callbackMu.Unlock()
+	callbackNextID++
+	callbackMap[callbackNextID] = cb
+	return callbackNextID
+}
+
+func lookupCallback(handle uintptr) interface{} {
+	callbackMu.Lock()
+	defer callbackMu.Unlock()
+	return callbackMap[handle]
+}
+
+func unregisterCallback(handle uintptr) {
+	callbackMu.Lock()
+	defer callbackMu.Unlock()
+	delete(callbackMap, handle)
+}
+
+//export goContextNotify
+func goContextNotify(errInfo *C.char, privateInfo unsafe.Pointer, cb C.size_t, handle C.uintptr_t) {
+	notify, ok := lookupCallback(uintptr(handle)).(func(ContextError))
+	if !ok {
+		return
+	}
+	ce := ContextError{Info: C.GoString(errInfo)}
+	if privateInfo != nil && cb > 0 {
+		ce.PrivateInfo = C.GoBytes(privateInfo, C.int(cb))
+	}
+	notify(ce)
+}
+
+//export goProgramNotify
+func goProgramNotify(program C.cl_program, handle C.uintptr_t) {
+	if notify, ok := lookupCallback(uintptr(handle)).(func()); ok {
+		unregisterCallback(uintptr(handle))
+		notify()
+	}
+}
+
+//export goMemObjectNotify
+func goMemObjectNotify(memobj C.cl_mem, handle C.uintptr_t) {
+	if notify, ok := lookupCallback(uintptr(handle)).(func()); ok {
+		unregisterCallback(uintptr(handle))
+		notify()
+	}
+}
+
+//export goEventNotify
+func goEventNotify(event C.cl_event, status C.cl_int, handle C.uintptr_t) {
+	if notify, ok := lookupCallback(uintptr(handle)).(func(CommmandExecStatus)); ok {
+		unregisterCallback(uintptr(handle))
+		notify(CommmandExecStatus(status))
+	}
+}
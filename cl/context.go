@@ -9,11 +9,26 @@ package cl
 #else
 #include <CL/cl.h>
 #endif
+#include <stdint.h>
 #include <stdlib.h>
+
+extern void goContextNotify(char *errinfo, void *private_info, size_t cb, uintptr_t handle);
+
+static void CL_CALLBACK contextNotify(const char *errinfo, const void *private_info, size_t cb, void *user_data) {
+	goContextNotify((char *)errinfo, (void *)private_info, cb, (uintptr_t)user_data);
+}
+
+static cl_context createContext(const cl_context_properties *properties, cl_uint num_devices, const cl_device_id *devices, uintptr_t handle, cl_int *errcode_ret) {
+	if (handle == 0) {
+		return clCreateContext(properties, num_devices, devices, NULL, NULL, errcode_ret);
+	}
+	return clCreateContext(properties, num_devices, devices, contextNotify, (void *)handle, errcode_ret);
+}
 */
 import "C"
 
 import (
+	"fmt"
 	"runtime"
 	"unsafe"
 )
@@ -22,25 +37,109 @@ const maxImageFormats = 256
 
 // Context ..
 type Context struct {
-	clContext C.cl_context
-	devices   []*Device
+	clContext    C.cl_context
+	devices      []*Device
+	notifyHandle uintptr
+}
+
+// ContextProperty is a property name and value pair passed to clCreateContext.
+// Value is interpreted according to Name, e.g. a platform or a GL context handle.
+type ContextProperty struct {
+	Name  ContextPropertyName
+	Value uintptr
+}
+
+// ContextOptions are the optional settings for CreateContextWithOptions.
+type ContextOptions struct {
+	// Platform to create the context for. If nil the choice is implementation-defined.
+	Platform *Platform
+	// Properties are any additional (e.g. interop) context properties.
+	Properties []ContextProperty
+	// Notify, if set, is called by the OpenCL implementation to report errors
+	// that occur in the context. It may be called asynchronously from a thread
+	// owned by the implementation, so it must be safe for concurrent use.
+	Notify func(ContextError)
 }
 
+// ContextError is an error reported by the OpenCL implementation through the
+// context notification callback. PrivateInfo is implementation-specific
+// binary data that may help debugging.
+type ContextError struct {
+	Info        string
+	PrivateInfo []byte
+}
 
-// TODO: properties
+func (e ContextError) Error() string {
+	return fmt.Sprintf("cl: context error: %s", e.Info)
+}
+
+func (o ContextOptions) toCl() []C.cl_context_properties {
+	var properties []C.cl_context_properties
+	if o.Platform != nil {
+		properties = append(properties, C.CL_CONTEXT_PLATFORM, C.cl_context_properties(uintptr(unsafe.Pointer(o.Platform.id))))
+	}
+	for _, p := range o.Properties {
+		properties = append(properties, C.cl_context_properties(p.Name), C.cl_context_properties(p.Value))
+	}
+	if properties == nil {
+		return nil
+	}
+	return append(properties, 0)
+}
+
+// newContextFromID wraps a cl_context obtained from another object, retaining
+// it so that the returned Context can be released independently.
+func newContextFromID(clContext C.cl_context) (*Context, error) {
+	devices, err := contextDevices(clContext)
+	if err != nil {
+		return nil, err
+	}
+	if err := C.clRetainContext(clContext); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	context := &Context{clContext: clContext, devices: devices}
+	runtime.SetFinalizer(context, releaseContext)
+	return context, nil
+}
+
+func contextDevices(clContext C.cl_context) ([]*Device, error) {
+	var deviceIDs [maxDeviceCount]C.cl_device_id
+	var n C.size_t
+	if err := C.clGetContextInfo(clContext, C.CL_CONTEXT_DEVICES, C.size_t(unsafe.Sizeof(deviceIDs)), unsafe.Pointer(&deviceIDs[0]), &n); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	return buildDeviceListFromDeviceIDs(deviceIDs[:int(n)/int(unsafe.Sizeof(deviceIDs[0]))]), nil
+}
 
 // CreateContext ..
 func CreateContext(devices []*Device) (*Context, error) {
+	return CreateContextWithOptions(devices, ContextOptions{})
+}
+
+// CreateContextWithOptions creates a context for the given devices using the
+// given platform, properties and error notification callback.
+func CreateContextWithOptions(devices []*Device, options ContextOptions) (*Context, error) {
 	deviceIDs := buildDeviceIDList(devices)
+	properties := options.toCl()
+	var propertiesPtr *C.cl_context_properties
+	if properties != nil {
+		propertiesPtr = &properties[0]
+	}
+	var notifyHandle uintptr
+	if options.Notify != nil {
+		notifyHandle = registerCallback(options.Notify)
+	}
 	var err C.cl_int
-	clContext := C.clCreateContext(nil, C.cl_uint(len(devices)), &deviceIDs[0], nil, nil, &err)
+	clContext := C.createContext(propertiesPtr, C.cl_uint(len(devices)), &deviceIDs[0], C.uintptr_t(notifyHandle), &err)
 	if err != C.CL_SUCCESS {
+		unregisterCallback(notifyHandle)
 		return nil, toError(err)
 	}
 	if clContext == nil {
+		unregisterCallback(notifyHandle)
 		return nil, ErrUnknown
 	}
-	context := &Context{clContext: clContext, devices: devices}
+	context := &Context{clContext: clContext, devices: devices, notifyHandle: notifyHandle}
 	runtime.SetFinalizer(context, releaseContext)
 	return context, nil
 }
@@ -98,17 +197,66 @@ func (ctx *Context) CreateProgramWithSource(sources []string) (*Program, error)
 	return program, nil
 }
 
+// CreateProgramWithBinary creates a program from the binaries (as returned
+// by Program.Binaries) for the given devices. binaries[i] is loaded on
+// devices[i]. The program still has to be built with BuildProgram before
+// kernels can be created from it. If any binary is rejected the error is a
+// ProgramBinaryError that carries the status of each device.
+func (ctx *Context) CreateProgramWithBinary(devices []*Device, binaries [][]byte) (*Program, error) {
+	if len(devices) == 0 || len(devices) != len(binaries) {
+		return nil, ErrInvalidValue
+	}
+	deviceIDs := buildDeviceIDList(devices)
+	lengths := make([]C.size_t, len(binaries))
+	cBinaries := make([]*C.uchar, len(binaries))
+	for i, binary := range binaries {
+		if len(binary) == 0 {
+			return nil, ErrInvalidValue
+		}
+		lengths[i] = C.size_t(len(binary))
+		cBinaries[i] = (*C.uchar)(C.CBytes(binary))
+		defer C.free(unsafe.Pointer(cBinaries[i]))
+	}
+	binaryStatus := make([]C.cl_int, len(binaries))
+	var err C.cl_int
+	clProgram := C.clCreateProgramWithBinary(ctx.clContext, C.cl_uint(len(deviceIDs)), &deviceIDs[0], &lengths[0], &cBinaries[0], &binaryStatus[0], &err)
+	if err != C.CL_SUCCESS {
+		binaryErr := ProgramBinaryError{Err: toError(err), Statuses: make([]error, len(binaryStatus))}
+		failed := false
+		for i, status := range binaryStatus {
+			binaryErr.Statuses[i] = toError(status)
+			failed = failed || status != C.CL_SUCCESS
+		}
+		if failed {
+			return nil, binaryErr
+		}
+		return nil, binaryErr.Err
+	}
+	if clProgram == nil {
+		return nil, ErrUnknown
+	}
+	program := &Program{clProgram: clProgram, devices: devices}
+	runtime.SetFinalizer(program, releaseProgram)
+	return program, nil
+}
+
 // CreateBufferUnsafe ..
 func (ctx *Context) CreateBufferUnsafe(flags MemFlag, size int, dataPtr unsafe.Pointer) (*MemObject, error) {
+	hostPtr := newHostPtrRef(flags, dataPtr)
 	var err C.cl_int
 	clBuffer := C.clCreateBuffer(ctx.clContext, C.cl_mem_flags(flags), C.size_t(size), dataPtr, &err)
 	if err != C.CL_SUCCESS {
+		hostPtr.release()
 		return nil, toError(err)
 	}
 	if clBuffer == nil {
+		hostPtr.release()
 		return nil, ErrUnknown
 	}
-	return newMemObject(clBuffer, size), nil
+	buffer := newMemObject(clBuffer, size)
+	buffer.flags = flags & memAccessFlags
+	buffer.keepHostPtr(hostPtr)
+	return buffer, nil
 }
 
 // CreateEmptyBuffer ..
@@ -123,7 +271,17 @@ func (ctx *Context) CreateBuffer(flags MemFlag, data []byte) (*MemObject, error)
 
 // CreateBufferFloat32 ..
 func (ctx *Context) CreateBufferFloat32(flags MemFlag, data []float32) (*MemObject, error) {
-	return ctx.CreateBufferUnsafe(flags, len(data)*4, unsafe.Pointer(&data[0]))
+	return ctx.CreateBufferHostMem(flags, SliceF32(data))
+}
+
+// CreateBufferHostMem creates a buffer the size of the HostMem. With
+// MemCopyHostPtr or MemUseHostPtr in flags the buffer is initialized from or
+// backed by its data.
+func (ctx *Context) CreateBufferHostMem(flags MemFlag, h HostMem) (*MemObject, error) {
+	if err := checkHostMem(h); err != nil {
+		return nil, err
+	}
+	return ctx.CreateBufferUnsafe(flags, hostMemSize(h), h.Ptr())
 }
 
 // CreateUserEvent ..
@@ -141,6 +299,3 @@ func (ctx *Context) Release() {
 	releaseContext(ctx)
 }
 
-// http://www.khronos.org/registry/cl/sdk/1.2/docs/man/xhtml/clCreateSubBuffer.html
-// func (memObject *MemObject) CreateSubBuffer(flags MemFlag, bufferCreateType BufferCreateType, )
-
@@ -39,4 +39,45 @@ func TestCreateBufferUnsafeWorks(t *testing.T) {
 	if err != nil {
 		t.Fatalf("CreateBufferUnsafe error %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func TestContextOptionsProperties(t *testing.T) {
+	if props := (ContextOptions{}).toCl(); props != nil {
+		t.Fatalf("empty ContextOptions expected nil properties got %v", props)
+	}
+	options := ContextOptions{
+		Platform:   &Platform{},
+		Properties: []ContextProperty{{Name: ContextPropertyName(1), Value: 2}},
+	}
+	props := options.toCl()
+	if len(props) != 5 {
+		t.Fatalf("ContextOptions expected 5 properties got %d", len(props))
+	}
+	if props[4] != 0 {
+		t.Fatalf("ContextOptions properties were not zero terminated: %v", props)
+	}
+}
+
+func TestCreateContextWithOptionsWorks(t *testing.T) {
+	platform, devices, _, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	context, err := CreateContextWithOptions(devices, ContextOptions{
+		Platform: platform,
+		Notify: func(e ContextError) {
+			t.Logf("context notification: %v", e)
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateContextWithOptions error %v", err)
+	}
+	if lookupCallback(context.notifyHandle) == nil {
+		t.Fatalf("CreateContextWithOptions did not register the Notify callback")
+	}
+	handle := context.notifyHandle
+	context.Release()
+	if lookupCallback(handle) != nil {
+		t.Fatalf("Context.Release did not unregister the Notify callback")
+	}
+}
@@ -95,6 +95,9 @@ func (dt DeviceType) String() string {
 // Device is a cl_device_id wrapping struct
 type Device struct {
 	id C.cl_device_id
+	// sub is true for sub-devices created by partitioning a device, which
+	// are reference counted and must be released.
+	sub bool
 }
 
 func buildDeviceIDList(devices []*Device) []C.cl_device_id {
@@ -479,6 +482,15 @@ func (d *Device) ImageSupport() bool {
 	return val
 }
 
+// Platform returns the platform associated with the device.
+func (d *Device) Platform() (*Platform, error) {
+	var platformID C.cl_platform_id
+	if err := C.clGetDeviceInfo(d.id, C.CL_DEVICE_PLATFORM, C.size_t(unsafe.Sizeof(platformID)), unsafe.Pointer(&platformID), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	return &Platform{id: platformID}, nil
+}
+
 // Type returns the specific DeviceType of the device e.g. DeviceTypeGPU
 func (d *Device) Type() DeviceType {
 	var deviceType C.cl_device_type
@@ -0,0 +1,7 @@
+// +build cl10
+
+package cl
+
+// Release is a no-op as OpenCL 1.0 has no sub-devices.
+func (d *Device) Release() {
+}
@@ -16,6 +16,8 @@ import "C"
 import (
 	"unsafe"
 	"strings"
+	"fmt"
+	"runtime"
 )
 
 // FPConfigCorrectlyRoundedDivideSqrt ..
@@ -25,6 +27,204 @@ func init() {
 	fpConfigNameMap[FPConfigCorrectlyRoundedDivideSqrt] = "CorrectlyRoundedDivideSqrt"
 }
 
+// PartitionProperty is a way in which a device can be partitioned into sub-devices.
+type PartitionProperty int
+
+// PartitionProperty variants
+const (
+	PartitionPropertyEqually          PartitionProperty = C.CL_DEVICE_PARTITION_EQUALLY
+	PartitionPropertyByCounts         PartitionProperty = C.CL_DEVICE_PARTITION_BY_COUNTS
+	PartitionPropertyByAffinityDomain PartitionProperty = C.CL_DEVICE_PARTITION_BY_AFFINITY_DOMAIN
+)
+
+func (pp PartitionProperty) String() string {
+	switch pp {
+	case PartitionPropertyEqually:
+		return "Equally"
+	case PartitionPropertyByCounts:
+		return "ByCounts"
+	case PartitionPropertyByAffinityDomain:
+		return "ByAffinityDomain"
+	}
+	return fmt.Sprintf("Unknown(%x)", int(pp))
+}
+
+// AffinityDomain is the cache or memory level along which a device can be
+// partitioned with Device.PartitionByAffinityDomain.
+type AffinityDomain int
+
+// AffinityDomain variants
+const (
+	AffinityDomainNUMA              AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_NUMA
+	AffinityDomainL4Cache           AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_L4_CACHE
+	AffinityDomainL3Cache           AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_L3_CACHE
+	AffinityDomainL2Cache           AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_L2_CACHE
+	AffinityDomainL1Cache           AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_L1_CACHE
+	AffinityDomainNextPartitionable AffinityDomain = C.CL_DEVICE_AFFINITY_DOMAIN_NEXT_PARTITIONABLE
+)
+
+var affinityDomainNameMap = map[AffinityDomain]string{
+	AffinityDomainNUMA:              "NUMA",
+	AffinityDomainL4Cache:           "L4Cache",
+	AffinityDomainL3Cache:           "L3Cache",
+	AffinityDomainL2Cache:           "L2Cache",
+	AffinityDomainL1Cache:           "L1Cache",
+	AffinityDomainNextPartitionable: "NextPartitionable",
+}
+
+func (ad AffinityDomain) String() string {
+	var parts []string
+	for _, bit := range []AffinityDomain{AffinityDomainNUMA, AffinityDomainL4Cache, AffinityDomainL3Cache, AffinityDomainL2Cache, AffinityDomainL1Cache, AffinityDomainNextPartitionable} {
+		if ad&bit != 0 {
+			parts = append(parts, affinityDomainNameMap[bit])
+		}
+	}
+	if parts == nil {
+		return ""
+	}
+	return strings.Join(parts, "|")
+}
+
+// DevicePartition describes how a sub-device was created. Values holds the
+// compute unit count for PartitionPropertyEqually, the counts for PartitionPropertyByCounts
+// and the AffinityDomain for PartitionPropertyByAffinityDomain.
+type DevicePartition struct {
+	Property PartitionProperty
+	Values   []int
+}
+
+func releaseDevice(d *Device) {
+	if d.id != nil && d.sub {
+		C.clReleaseDevice(d.id)
+		d.id = nil
+	}
+}
+
+// Release decrements the reference count of a sub-device. It is a no-op for
+// root devices, which are not reference counted.
+func (d *Device) Release() {
+	releaseDevice(d)
+}
+
+func (d *Device) createSubDevices(properties []C.cl_device_partition_property) ([]*Device, error) {
+	var numDevices C.cl_uint
+	if err := C.clCreateSubDevices(d.id, &properties[0], 0, nil, &numDevices); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if numDevices == 0 {
+		return nil, ErrDevicePartitionFailed
+	}
+	deviceIDs := make([]C.cl_device_id, numDevices)
+	if err := C.clCreateSubDevices(d.id, &properties[0], numDevices, &deviceIDs[0], nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	devices := make([]*Device, numDevices)
+	for i, id := range deviceIDs {
+		devices[i] = &Device{id: id, sub: true}
+		runtime.SetFinalizer(devices[i], releaseDevice)
+	}
+	return devices, nil
+}
+
+// PartitionEqually splits the device into as many sub-devices as can be
+// created that each contain n compute units.
+func (d *Device) PartitionEqually(n int) ([]*Device, error) {
+	return d.createSubDevices([]C.cl_device_partition_property{
+		C.CL_DEVICE_PARTITION_EQUALLY, C.cl_device_partition_property(n), 0,
+	})
+}
+
+// PartitionByCounts splits the device into one sub-device per entry of
+// counts, each containing that many compute units.
+func (d *Device) PartitionByCounts(counts []int) ([]*Device, error) {
+	if len(counts) == 0 {
+		return nil, ErrInvalidDevicePartitionCount
+	}
+	properties := []C.cl_device_partition_property{C.CL_DEVICE_PARTITION_BY_COUNTS}
+	for _, count := range counts {
+		properties = append(properties, C.cl_device_partition_property(count))
+	}
+	properties = append(properties, C.CL_DEVICE_PARTITION_BY_COUNTS_LIST_END, 0)
+	return d.createSubDevices(properties)
+}
+
+// PartitionByAffinityDomain splits the device into sub-devices that share
+// the given level of cache or NUMA node.
+func (d *Device) PartitionByAffinityDomain(domain AffinityDomain) ([]*Device, error) {
+	return d.createSubDevices([]C.cl_device_partition_property{
+		C.CL_DEVICE_PARTITION_BY_AFFINITY_DOMAIN, C.cl_device_partition_property(domain), 0,
+	})
+}
+
+func (d *Device) getInfoPartitionProperties(param C.cl_device_info) ([]C.cl_device_partition_property, error) {
+	var size C.size_t
+	if err := C.clGetDeviceInfo(d.id, param, 0, nil, &size); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	var val C.cl_device_partition_property
+	n := int(size / C.size_t(unsafe.Sizeof(val)))
+	if n == 0 {
+		return nil, nil
+	}
+	properties := make([]C.cl_device_partition_property, n)
+	if err := C.clGetDeviceInfo(d.id, param, size, unsafe.Pointer(&properties[0]), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	return properties, nil
+}
+
+// PartitionMaxSubDevices is the maximum number of sub-devices that can be
+// created when the device is partitioned.
+func (d *Device) PartitionMaxSubDevices() int {
+	val, _ := d.getInfoUint(C.CL_DEVICE_PARTITION_MAX_SUB_DEVICES, true)
+	return int(val)
+}
+
+// PartitionProperties lists the partition types supported by the device.
+func (d *Device) PartitionProperties() []PartitionProperty {
+	properties, err := d.getInfoPartitionProperties(C.CL_DEVICE_PARTITION_PROPERTIES)
+	if err != nil {
+		panic("PartitionProperties failed")
+	}
+	var partitionProperties []PartitionProperty
+	for _, p := range properties {
+		if p != 0 {
+			partitionProperties = append(partitionProperties, PartitionProperty(p))
+		}
+	}
+	return partitionProperties
+}
+
+// PartitionAffinityDomain is the set of affinity domains supported for
+// partitioning the device with PartitionByAffinityDomain.
+func (d *Device) PartitionAffinityDomain() AffinityDomain {
+	var domain C.cl_device_affinity_domain
+	if err := C.clGetDeviceInfo(d.id, C.CL_DEVICE_PARTITION_AFFINITY_DOMAIN, C.size_t(unsafe.Sizeof(domain)), unsafe.Pointer(&domain), nil); err != C.CL_SUCCESS {
+		panic("PartitionAffinityDomain failed")
+	}
+	return AffinityDomain(domain)
+}
+
+// PartitionType returns how the device was partitioned from its parent. It
+// returns nil for a device that is not a sub-device.
+func (d *Device) PartitionType() *DevicePartition {
+	properties, err := d.getInfoPartitionProperties(C.CL_DEVICE_PARTITION_TYPE)
+	if err != nil {
+		panic("PartitionType failed")
+	}
+	if len(properties) == 0 || properties[0] == 0 {
+		return nil
+	}
+	partition := &DevicePartition{Property: PartitionProperty(properties[0])}
+	for _, v := range properties[1:] {
+		if v == 0 {
+			break
+		}
+		partition.Values = append(partition.Values, int(v))
+	}
+	return partition
+}
+
 // BuiltInKernels .. 
 func (d *Device) BuiltInKernels() []string {
 	// From specification:
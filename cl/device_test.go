@@ -0,0 +1,40 @@
+// +build !cl10
+
+package cl
+
+import "testing"
+
+func TestPartitionEquallyWorks(t *testing.T) {
+	_, devices, _, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	for _, device := range devices {
+		supported := false
+		for _, p := range device.PartitionProperties() {
+			supported = supported || p == PartitionPropertyEqually
+		}
+		if !supported || device.MaxComputeUnits() < 2 {
+			continue
+		}
+		subDevices, err := device.PartitionEqually(1)
+		if err != nil {
+			t.Fatalf("PartitionEqually error %v", err)
+		}
+		if len(subDevices) == 0 || len(subDevices) > device.PartitionMaxSubDevices() {
+			t.Fatalf("PartitionEqually returned %d sub-devices", len(subDevices))
+		}
+		partition := subDevices[0].PartitionType()
+		if partition == nil || partition.Property != PartitionPropertyEqually {
+			t.Fatalf("PartitionType of sub-device was %+v", partition)
+		}
+		if parent := subDevices[0].ParentDevice(); parent == nil || parent.id != device.id {
+			t.Fatalf("ParentDevice of sub-device was not the partitioned device")
+		}
+		for _, d := range subDevices {
+			d.Release()
+		}
+		return
+	}
+	t.Skip("no device supports PartitionPropertyEqually")
+}
@@ -13,14 +13,17 @@ package cl
 import "C"
 
 import (
-	"unsafe"
+	"context"
 	"runtime"
+	"sync"
+	"unsafe"
 )
 
-
 // Event is the cl_event wrapping struct
 type Event struct {
-	clEvent C.cl_event
+	clEvent  C.cl_event
+	doneOnce sync.Once
+	done     chan struct{}
 }
 
 func releaseEvent(ev *Event) {
@@ -45,6 +48,64 @@ func (e *Event) GetEventProfilingInfo(paramName ProfilingInfo) (int64, error) {
 	return int64(paramValue), nil
 }
 
+// Err returns the error the command identified by the event terminated
+// with, i.e. a negative execution status. It returns nil while the command
+// is still pending or if it completed successfully.
+func (e *Event) Err() error {
+	status, err := e.Status()
+	if err != nil {
+		return err
+	}
+	if status < 0 {
+		return toError(C.cl_int(status))
+	}
+	return nil
+}
+
+func (e *Event) getInfoInt(param C.cl_event_info) (int, error) {
+	var val C.cl_int
+	if err := C.clGetEventInfo(e.clEvent, param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return int(val), nil
+}
+
+// Status returns the execution status of the command identified by the event
+// without blocking. A negative status is the error code the command
+// terminated with.
+func (e *Event) Status() (CommmandExecStatus, error) {
+	status, err := e.getInfoInt(C.CL_EVENT_COMMAND_EXECUTION_STATUS)
+	return CommmandExecStatus(status), err
+}
+
+// CommandType returns the type of command the event identifies.
+func (e *Event) CommandType() (CommandType, error) {
+	var val C.cl_command_type
+	if err := C.clGetEventInfo(e.clEvent, C.CL_EVENT_COMMAND_TYPE, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return CommandType(val), nil
+}
+
+// ReferenceCount returns the reference count of the underlying cl_event.
+// This is only useful for debugging.
+func (e *Event) ReferenceCount() (int, error) {
+	return e.getInfoInt(C.CL_EVENT_REFERENCE_COUNT)
+}
+
+// Queue returns the command queue the command identified by the event was
+// enqueued on, or nil for user events.
+func (e *Event) Queue() (*CommandQueue, error) {
+	var clQueue C.cl_command_queue
+	if err := C.clGetEventInfo(e.clEvent, C.CL_EVENT_COMMAND_QUEUE, C.size_t(unsafe.Sizeof(clQueue)), unsafe.Pointer(&clQueue), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if clQueue == nil {
+		return nil, nil
+	}
+	return newCommandQueueFromID(clQueue)
+}
+
 // SetUserEventStatus sets the execution status of a user event object.
 //
 // `status` specifies the new execution status to be set and
@@ -70,6 +131,39 @@ func WaitForEvents(events []*Event) error {
 	return toError(C.clWaitForEvents(C.cl_uint(len(events)), eventListPtr(events)))
 }
 
+// WaitForEventsContext is like WaitForEvents but returns ctx.Err() as soon as
+// ctx is done. The commands keep executing on the device unless they are
+// gated on a user event passed in cancelEvents: each of those is then set to
+// a negative status, which terminates all enqueued commands waiting on it.
+// Cancellation only stops the wait: on OpenCL 1.0 the goroutines calling
+// clWaitForEvents for Done, and the OS threads they run on, stay blocked
+// until the events complete, and on later versions the completion callbacks
+// stay registered until then.
+func WaitForEventsContext(ctx context.Context, events []*Event, cancelEvents ...*Event) error {
+	for _, e := range events {
+		select {
+		case <-e.Done():
+		case <-ctx.Done():
+			failUserEvents(cancelEvents)
+			return ctx.Err()
+		}
+	}
+	for _, e := range events {
+		if e.Err() != nil {
+			return ErrExecStatusErrorForEventsInWaitList
+		}
+	}
+	return nil
+}
+
+// failUserEvents terminates the user events, ignoring those that already
+// have a status set.
+func failUserEvents(events []*Event) {
+	for _, e := range events {
+		e.SetUserEventStatus(C.CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST)
+	}
+}
+
 func newEvent(clEvent C.cl_event) *Event {
 	ev := &Event{clEvent: clEvent}
 	runtime.SetFinalizer(ev, releaseEvent)
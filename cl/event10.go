@@ -0,0 +1,27 @@
+// +build cl10
+
+package cl
+
+// Context is not supported by OpenCL 1.0
+func (e *Event) Context() (*Context, error) {
+	return nil, ErrUnsupported
+}
+
+// OnStatus is not supported by OpenCL 1.0
+func (e *Event) OnStatus(status CommmandExecStatus, fn func(CommmandExecStatus)) error {
+	return ErrUnsupported
+}
+
+// Done returns a channel that is closed when the command identified by the
+// event has completed, either successfully or abnormally (see Err). OpenCL 1.0
+// has no event callbacks so a goroutine waits for the event.
+func (e *Event) Done() <-chan struct{} {
+	e.doneOnce.Do(func() {
+		e.done = make(chan struct{})
+		go func() {
+			WaitForEvents([]*Event{e})
+			close(e.done)
+		}()
+	})
+	return e.done
+}
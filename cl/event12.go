@@ -0,0 +1,63 @@
+// +build !cl10
+
+package cl
+
+/*
+#cgo CFLAGS: -I CL -w
+#cgo !darwin LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdint.h>
+
+extern void goEventNotify(cl_event event, cl_int status, uintptr_t handle);
+
+static void CL_CALLBACK eventNotify(cl_event event, cl_int status, void *user_data) {
+	goEventNotify(event, status, (uintptr_t)user_data);
+}
+
+static cl_int setEventCallback(cl_event event, cl_int status, uintptr_t handle) {
+	return clSetEventCallback(event, status, eventNotify, (void *)handle);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// Context returns the context the event belongs to.
+func (e *Event) Context() (*Context, error) {
+	var clContext C.cl_context
+	if err := C.clGetEventInfo(e.clEvent, C.CL_EVENT_CONTEXT, C.size_t(unsafe.Sizeof(clContext)), unsafe.Pointer(&clContext), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	return newContextFromID(clContext)
+}
+
+// OnStatus registers fn to be called once the command identified by the event
+// reaches the given execution status (CommmandExecStatusSubmitted, Running or
+// Complete). fn receives the actual status, which is negative if the command
+// terminated abnormally. fn is called from a thread owned by the OpenCL
+// implementation and must not block.
+func (e *Event) OnStatus(status CommmandExecStatus, fn func(CommmandExecStatus)) error {
+	handle := registerCallback(fn)
+	if err := toError(C.setEventCallback(e.clEvent, C.cl_int(status), C.uintptr_t(handle))); err != nil {
+		unregisterCallback(handle)
+		return err
+	}
+	return nil
+}
+
+// Done returns a channel that is closed when the command identified by the
+// event has completed, either successfully or abnormally (see Err).
+func (e *Event) Done() <-chan struct{} {
+	e.doneOnce.Do(func() {
+		e.done = make(chan struct{})
+		if err := e.OnStatus(CommmandExecStatusComplete, func(CommmandExecStatus) { close(e.done) }); err != nil {
+			close(e.done)
+		}
+	})
+	return e.done
+}
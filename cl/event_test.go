@@ -0,0 +1,125 @@
+package cl
+
+import (
+	stdcontext "context"
+	"testing"
+	"time"
+)
+
+func TestEventDoneAndErrWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	ok, err := context.CreateUserEvent()
+	if err != nil {
+		t.Fatalf("CreateUserEvent error %v", err)
+	}
+	select {
+	case <-ok.Done():
+		t.Fatalf("Done was closed before the user event was complete")
+	default:
+	}
+	if err := ok.SetUserEventStatus(int(CommmandExecStatusComplete)); err != nil {
+		t.Fatalf("SetUserEventStatus error %v", err)
+	}
+	select {
+	case <-ok.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Done was not closed after the user event completed")
+	}
+	if err := ok.Err(); err != nil {
+		t.Fatalf("Err of completed event expected nil got %v", err)
+	}
+
+	failed, err := context.CreateUserEvent()
+	if err != nil {
+		t.Fatalf("CreateUserEvent error %v", err)
+	}
+	if err := failed.SetUserEventStatus(-1); err != nil {
+		t.Fatalf("SetUserEventStatus error %v", err)
+	}
+	select {
+	case <-failed.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Done was not closed after the user event failed")
+	}
+	if err := failed.Err(); err == nil {
+		t.Fatalf("Err of failed event expected an error got nil")
+	}
+}
+
+func TestCommandTypeAndExecStatusStrings(t *testing.T) {
+	if s := CommandTypeNDRangeKernel.String(); s != "NDRangeKernel" {
+		t.Fatalf("CommandTypeNDRangeKernel.String() expected NDRangeKernel got %s", s)
+	}
+	if s := CommmandExecStatusRunning.String(); s != "Running" {
+		t.Fatalf("CommmandExecStatusRunning.String() expected Running got %s", s)
+	}
+	if s := CommmandExecStatus(-5).String(); s != "Error(-5)" {
+		t.Fatalf("CommmandExecStatus(-5).String() expected Error(-5) got %s", s)
+	}
+}
+
+func TestEventIntrospectionWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	event, err := context.CreateUserEvent()
+	if err != nil {
+		t.Fatalf("CreateUserEvent error %v", err)
+	}
+	status, err := event.Status()
+	if err != nil {
+		t.Fatalf("Status error %v", err)
+	}
+	if status != CommmandExecStatusSubmitted {
+		t.Fatalf("Status of new user event expected Submitted got %s", status)
+	}
+	queue, err := event.Queue()
+	if err != nil {
+		t.Fatalf("Queue error %v", err)
+	}
+	if queue != nil {
+		t.Fatalf("Queue of user event expected nil")
+	}
+	if count, err := event.ReferenceCount(); err != nil || count < 1 {
+		t.Fatalf("ReferenceCount expected >= 1 got %d (%v)", count, err)
+	}
+	if _, err := event.CommandType(); err != nil {
+		t.Fatalf("CommandType error %v", err)
+	}
+}
+
+func TestWaitForEventsContextWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	pending, err := context.CreateUserEvent()
+	if err != nil {
+		t.Fatalf("CreateUserEvent error %v", err)
+	}
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := WaitForEventsContext(ctx, []*Event{pending}, pending); err != stdcontext.DeadlineExceeded {
+		t.Fatalf("WaitForEventsContext expected DeadlineExceeded got %v", err)
+	}
+	select {
+	case <-pending.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("WaitForEventsContext did not fail the cancel event")
+	}
+	if pending.Err() == nil {
+		t.Fatalf("cancel event expected an error got nil")
+	}
+	complete, err := context.CreateUserEvent()
+	if err != nil {
+		t.Fatalf("CreateUserEvent error %v", err)
+	}
+	complete.SetUserEventStatus(int(CommmandExecStatusComplete))
+	if err := WaitForEventsContext(stdcontext.Background(), []*Event{complete}); err != nil {
+		t.Fatalf("WaitForEventsContext of complete event error %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package cl
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Half is an IEEE 754 half precision (binary16) number, the Go type of the
+// OpenCL half type and the Float16 PrimitiveType. Devices only support half
+// arithmetic with the cl_khr_fp16 extension, see Device.HalfFPConfig, but
+// half values can always be stored in buffers and loaded with vload_half.
+type Half uint16
+
+// NewHalf converts f to the nearest Half, rounding ties to even. Values too
+// large for a Half become infinities and NaNs stay NaNs.
+func NewHalf(f float32) Half {
+	bits := math.Float32bits(f)
+	sign := Half(bits>>16) & 0x8000
+	exp := int(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			// keep the top of the payload and make sure the result is a quiet NaN
+			return sign | 0x7e00 | Half(mant>>13)
+		}
+		return sign | 0x7c00
+	}
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return sign | 0x7c00
+	}
+	if e <= 0 {
+		// the result is subnormal or zero; the implicit bit becomes explicit
+		shift := uint(14 - e)
+		if shift > 24 {
+			return sign
+		}
+		mant |= 0x800000
+		h := mant >> shift
+		rem := mant & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || (rem == halfway && h&1 == 1) {
+			h++
+		}
+		return sign | Half(h)
+	}
+	// rounding up may carry into the exponent, which correctly yields the
+	// next power of two or infinity
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++
+	}
+	return sign | Half(h)
+}
+
+// Float32 converts the Half to a float32, which is always exact.
+func (h Half) Float32() float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := int(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		e := -14
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | uint32(e+127)<<23 | (mant&0x3ff)<<13)
+	default:
+		return math.Float32frombits(sign | uint32(exp-15+127)<<23 | mant<<13)
+	}
+}
+
+// IsNaN ..
+func (h Half) IsNaN() bool {
+	return h&0x7c00 == 0x7c00 && h&0x3ff != 0
+}
+
+// HalfsFromFloat32s converts src to Halfs, see NewHalf.
+func HalfsFromFloat32s(src []float32) []Half {
+	dst := make([]Half, len(src))
+	for i, f := range src {
+		dst[i] = NewHalf(f)
+	}
+	return dst
+}
+
+// Float32sFromHalfs converts src to float32s.
+func Float32sFromHalfs(src []Half) []float32 {
+	dst := make([]float32, len(src))
+	for i, h := range src {
+		dst[i] = h.Float32()
+	}
+	return dst
+}
+
+// SliceF16 ..
+type SliceF16 []Half
+
+// Ptr ..
+func (h SliceF16) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceF16) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceF16) NumType() NumTyped {
+	return Float16
+}
+
+// SizeofT ..
+func (h SliceF16) SizeofT() uintptr {
+	return size16
+}
@@ -0,0 +1,83 @@
+package cl
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNewHalfRounding(t *testing.T) {
+	cases := []struct {
+		f        float32
+		expected Half
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{65504, 0x7bff},
+		{65519, 0x7bff},
+		{65520, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{1 + 1.0/2048, 0x3c00}, // tie rounds to even
+		{1 + 3.0/2048, 0x3c02}, // tie rounds to even
+		{1 + 1.0/2048 + 1.0/65536, 0x3c01},
+		{float32(math.Ldexp(1, -14)), 0x0400}, // smallest normal
+		{float32(math.Ldexp(1, -24)), 0x0001}, // smallest subnormal
+		{float32(math.Ldexp(1, -25)), 0x0000}, // tie rounds to even zero
+		{float32(math.Ldexp(1.5, -25)), 0x0001},
+		{float32(math.Ldexp(3, -25)), 0x0002}, // tie rounds to even
+		{float32(math.Ldexp(1, -30)), 0x0000},
+		{float32(math.Ldexp(1023.5, -24)), 0x0400}, // rounds up into the normals
+	}
+	for _, c := range cases {
+		if h := NewHalf(c.f); h != c.expected {
+			t.Fatalf("NewHalf(%g) expected %#04x got %#04x", c.f, c.expected, h)
+		}
+	}
+	if h := NewHalf(float32(math.NaN())); !h.IsNaN() || h&0x200 == 0 {
+		t.Fatalf("NewHalf(NaN) expected a quiet NaN got %#04x", h)
+	}
+}
+
+func TestHalfFloat32RoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Half(i)
+		f := h.Float32()
+		if h.IsNaN() {
+			if !math.IsNaN(float64(f)) {
+				t.Fatalf("Half(%#04x).Float32() expected NaN got %g", i, f)
+			}
+			continue
+		}
+		if back := NewHalf(f); back != h {
+			t.Fatalf("NewHalf(Half(%#04x).Float32()) expected %#04x got %#04x", i, i, back)
+		}
+	}
+	if f := Half(0x0001).Float32(); f != float32(math.Ldexp(1, -24)) {
+		t.Fatalf("Half(0x0001).Float32() expected 2^-24 got %g", f)
+	}
+}
+
+func TestHalfSlicesAndFloat16(t *testing.T) {
+	src := []float32{0.5, -1, 65504}
+	if back := Float32sFromHalfs(HalfsFromFloat32s(src)); back[0] != 0.5 || back[1] != -1 || back[2] != 65504 {
+		t.Fatalf("Float32sFromHalfs(HalfsFromFloat32s(%v)) got %v", src, back)
+	}
+	if Float16.SizeofT() != 2 || Float16.ClSrc() != "half" || Float16x4.ClSrc() != "half4" {
+		t.Fatalf("Float16 expected size 2 and ClSrc half got %d %q", Float16.SizeofT(), Float16.ClSrc())
+	}
+	if parsed, err := ParsePrimitiveType("half8"); err != nil || parsed != Float16x8 {
+		t.Fatalf("ParsePrimitiveType(half8) expected Float16x8 got %v %v", parsed, err)
+	}
+	hostMem, err := NewHostMem(HalfsFromFloat32s(src))
+	if err != nil || hostMem.NumType() != Float16 || hostMem.Len() != 3 {
+		t.Fatalf("NewHostMem([]Half) expected 3 Float16 got %v %v", hostMem, err)
+	}
+	if vt, ok := vectorTypeOf(reflect.TypeOf([4]Half{})); !ok || vt != Float16x4 {
+		t.Fatalf("vectorTypeOf([4]Half) expected Float16x4 got %v", vt)
+	}
+	if numTypeOf[Half]() != Float16 {
+		t.Fatalf("numTypeOf[Half] expected Float16 got %v", numTypeOf[Half]())
+	}
+}
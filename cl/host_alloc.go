@@ -0,0 +1,240 @@
+package cl
+
+/*
+#include <stdlib.h>
+#include <string.h>
+
+static void *allocAligned(size_t align, size_t size) {
+	void *ptr = NULL;
+	if (posix_memalign(&ptr, align, size) != 0) {
+		return NULL;
+	}
+	memset(ptr, 0, size);
+	return ptr;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"unsafe"
+)
+
+// AlignedHostMem errors
+var (
+	ErrHostMemInvalidAlign = errors.New("HostMem alignment was not a power of two")
+	ErrHostMemWasFreed     = errors.New("HostMem was already freed")
+)
+
+// hostMemSizeMultiple is the granularity allocation sizes are rounded up to.
+// Several implementations only share host memory with the device without a
+// copy when the size is a multiple of a cache line.
+const hostMemSizeMultiple = 64
+
+// AlignedHostMem is a HostMem allocated outside of the Go heap, so it can be
+// handed to the OpenCL implementation with MemUseHostPtr without breaking
+// the cgo pointer rules. On devices with HostUnifiedMemory such buffers are
+// usually zero-copy. The memory is zeroed on allocation and must be released
+// with Free.
+type AlignedHostMem struct {
+	ptr     unsafe.Pointer
+	size    int // allocated bytes
+	length  int
+	numType PrimitiveType
+	uses    int            // MemUseHostPtr memory objects backed by the memory
+	pending unsafe.Pointer // memory to free once uses drops to 0
+}
+
+// alignedHostMems are the allocations that have not been freed, so memory
+// objects created from a pointer into one of them can hold on to it.
+var (
+	alignedHostMemsLock sync.Mutex
+	alignedHostMems     = make(map[*AlignedHostMem]struct{})
+)
+
+// AllocHostMem allocates length elements of numType aligned to align bytes.
+// An align of 0 aligns to the page size.
+func AllocHostMem(numType PrimitiveType, length int, align int) (*AlignedHostMem, error) {
+	if length <= 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	if align <= 0 {
+		align = os.Getpagesize()
+	}
+	if align&(align-1) != 0 {
+		return nil, ErrHostMemInvalidAlign
+	}
+	if ptrSize := int(unsafe.Sizeof(uintptr(0))); align < ptrSize {
+		align = ptrSize
+	}
+	size := length * int(numType.SizeofT())
+	size = (size + hostMemSizeMultiple - 1) / hostMemSizeMultiple * hostMemSizeMultiple
+	ptr := C.allocAligned(C.size_t(align), C.size_t(size))
+	if ptr == nil {
+		return nil, ErrOutOfHostMemory
+	}
+	h := &AlignedHostMem{ptr: ptr, size: size, length: length, numType: numType}
+	alignedHostMemsLock.Lock()
+	alignedHostMems[h] = struct{}{}
+	alignedHostMemsLock.Unlock()
+	return h, nil
+}
+
+// AllocHostMemForDevice allocates length elements of numType aligned to the
+// larger of the device's MemBaseAddrAlign and the page size.
+func AllocHostMemForDevice(device *Device, numType PrimitiveType, length int) (*AlignedHostMem, error) {
+	align := os.Getpagesize()
+	if a := device.MemBaseAddrAlign() / 8; a > align {
+		align = a
+	}
+	return AllocHostMem(numType, length, align)
+}
+
+// Free releases the memory. While memory objects created from it with
+// MemUseHostPtr are alive the memory is only released once the last of them
+// has been destroyed by the OpenCL implementation, but it can no longer be
+// accessed through h. Calling Free more than once is a no-op.
+func (h *AlignedHostMem) Free() {
+	alignedHostMemsLock.Lock()
+	defer alignedHostMemsLock.Unlock()
+	if h.ptr == nil {
+		return
+	}
+	delete(alignedHostMems, h)
+	h.pending = h.ptr
+	h.ptr = nil
+	h.length = 0
+	if h.uses == 0 {
+		h.freePending()
+	}
+}
+
+func (h *AlignedHostMem) freePending() {
+	C.free(h.pending)
+	h.pending = nil
+}
+
+// acquireAlignedHostMem returns the allocation ptr points into, if any, and
+// keeps it from being released by Free until it is passed to release.
+func acquireAlignedHostMem(ptr unsafe.Pointer) *AlignedHostMem {
+	alignedHostMemsLock.Lock()
+	defer alignedHostMemsLock.Unlock()
+	addr := uintptr(ptr)
+	for h := range alignedHostMems {
+		if start := uintptr(h.ptr); addr >= start && addr < start+uintptr(h.size) {
+			h.uses++
+			return h
+		}
+	}
+	return nil
+}
+
+// release undoes an acquireAlignedHostMem, freeing the memory if Free was
+// called in the meantime. It is a no-op on nil.
+func (h *AlignedHostMem) release() {
+	if h == nil {
+		return
+	}
+	alignedHostMemsLock.Lock()
+	defer alignedHostMemsLock.Unlock()
+	h.uses--
+	if h.uses == 0 && h.pending != nil {
+		h.freePending()
+	}
+}
+
+// Ptr ..
+func (h *AlignedHostMem) Ptr() unsafe.Pointer {
+	if h.ptr == nil {
+		panic(ErrHostMemWasFreed)
+	}
+	return h.ptr
+}
+
+// Len ..
+func (h *AlignedHostMem) Len() int {
+	return h.length
+}
+
+// NumType ..
+func (h *AlignedHostMem) NumType() NumTyped {
+	return h.numType
+}
+
+// SizeofT ..
+func (h *AlignedHostMem) SizeofT() uintptr {
+	return h.numType.SizeofT()
+}
+
+// viewLen is the number of elements of size sizeofT that fit in the memory.
+func (h *AlignedHostMem) viewLen(sizeofT uintptr) int {
+	return h.length * int(h.numType.SizeofT()) / int(sizeofT)
+}
+
+// Bytes is a view of the memory as bytes. Like the other views it is only
+// valid until Free is called.
+func (h *AlignedHostMem) Bytes() []byte {
+	return unsafe.Slice((*byte)(h.Ptr()), h.viewLen(size8))
+}
+
+// Int8s is a view of the memory as int8s.
+func (h *AlignedHostMem) Int8s() []int8 {
+	return unsafe.Slice((*int8)(h.Ptr()), h.viewLen(size8))
+}
+
+// Uint8s is a view of the memory as uint8s.
+func (h *AlignedHostMem) Uint8s() []uint8 {
+	return unsafe.Slice((*uint8)(h.Ptr()), h.viewLen(size8))
+}
+
+// Int16s is a view of the memory as int16s.
+func (h *AlignedHostMem) Int16s() []int16 {
+	return unsafe.Slice((*int16)(h.Ptr()), h.viewLen(size16))
+}
+
+// Uint16s is a view of the memory as uint16s.
+func (h *AlignedHostMem) Uint16s() []uint16 {
+	return unsafe.Slice((*uint16)(h.Ptr()), h.viewLen(size16))
+}
+
+// Int32s is a view of the memory as int32s.
+func (h *AlignedHostMem) Int32s() []int32 {
+	return unsafe.Slice((*int32)(h.Ptr()), h.viewLen(size32))
+}
+
+// Uint32s is a view of the memory as uint32s.
+func (h *AlignedHostMem) Uint32s() []uint32 {
+	return unsafe.Slice((*uint32)(h.Ptr()), h.viewLen(size32))
+}
+
+// Float32s is a view of the memory as float32s.
+func (h *AlignedHostMem) Float32s() []float32 {
+	return unsafe.Slice((*float32)(h.Ptr()), h.viewLen(size32))
+}
+
+// Int64s is a view of the memory as int64s.
+func (h *AlignedHostMem) Int64s() []int64 {
+	return unsafe.Slice((*int64)(h.Ptr()), h.viewLen(size64))
+}
+
+// Uint64s is a view of the memory as uint64s.
+func (h *AlignedHostMem) Uint64s() []uint64 {
+	return unsafe.Slice((*uint64)(h.Ptr()), h.viewLen(size64))
+}
+
+// Float64s is a view of the memory as float64s.
+func (h *AlignedHostMem) Float64s() []float64 {
+	return unsafe.Slice((*float64)(h.Ptr()), h.viewLen(size64))
+}
+
+// Halfs is a view of the memory as Halfs.
+func (h *AlignedHostMem) Halfs() []Half {
+	return unsafe.Slice((*Half)(h.Ptr()), h.viewLen(size16))
+}
+
+// Uints is a view of the memory as uints.
+func (h *AlignedHostMem) Uints() []uint {
+	return unsafe.Slice((*uint)(h.Ptr()), h.viewLen(sizeT))
+}
@@ -0,0 +1,85 @@
+package cl
+
+import (
+	"os"
+	"testing"
+	"unsafe"
+)
+
+func TestAllocHostMemIsAligned(t *testing.T) {
+	hostMem, err := AllocHostMem(Float32, 10, 0)
+	if err != nil {
+		t.Fatalf("AllocHostMem error %v", err)
+	}
+	defer hostMem.Free()
+	if addr := uintptr(hostMem.Ptr()); addr%uintptr(os.Getpagesize()) != 0 {
+		t.Fatalf("AllocHostMem was not page aligned got address %x", addr)
+	}
+	if hostMem.Len() != 10 || hostMem.SizeofT() != unsafe.Sizeof(float32(0)) {
+		t.Fatalf("AllocHostMem expected 10 float32s got %d of size %d", hostMem.Len(), hostMem.SizeofT())
+	}
+	floats := hostMem.Float32s()
+	if len(floats) != 10 {
+		t.Fatalf("Float32s expected len 10 got %d", len(floats))
+	}
+	floats[9] = 1.0
+	if bytes := hostMem.Bytes(); len(bytes) != 40 || bytes[39] != 0x3f {
+		t.Fatalf("Bytes did not view the same memory as Float32s")
+	}
+	hostMem.Free()
+	hostMem.Free()
+	if hostMem.Len() != 0 {
+		t.Fatalf("Free did not reset Len")
+	}
+}
+
+func TestAllocHostMemErrors(t *testing.T) {
+	if _, err := AllocHostMem(Int32, 0, 0); err != ErrHostMemWasEmpty {
+		t.Fatalf("AllocHostMem of no elements expected ErrHostMemWasEmpty got %v", err)
+	}
+	if _, err := AllocHostMem(Int32, 4, 48); err != ErrHostMemInvalidAlign {
+		t.Fatalf("AllocHostMem with alignment 48 expected ErrHostMemInvalidAlign got %v", err)
+	}
+}
+
+func TestAlignedHostMemFreeWaitsForUses(t *testing.T) {
+	hostMem, err := AllocHostMem(Float32, 16, 0)
+	if err != nil {
+		t.Fatalf("AllocHostMem error %v", err)
+	}
+	if acquireAlignedHostMem(unsafe.Pointer(&hostMem.Float32s()[15])) != hostMem {
+		t.Fatalf("acquireAlignedHostMem did not find the allocation of an interior pointer")
+	}
+	hostMem.Free()
+	if hostMem.pending == nil {
+		t.Fatalf("Free released memory that was still in use")
+	}
+	if acquireAlignedHostMem(hostMem.pending) != nil {
+		t.Fatalf("acquireAlignedHostMem found a freed allocation")
+	}
+	hostMem.release()
+	if hostMem.pending != nil {
+		t.Fatalf("release of the last use did not free the memory")
+	}
+}
+
+func TestAllocHostMemForDeviceWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	hostMem, err := AllocHostMemForDevice(devices[0], Float32, 1024)
+	if err != nil {
+		t.Fatalf("AllocHostMemForDevice error %v", err)
+	}
+	defer hostMem.Free()
+	buffer, err := context.CreateBufferUnsafe(MemReadWrite|MemUseHostPtr, hostMem.Len()*int(hostMem.SizeofT()), hostMem.Ptr())
+	if err != nil {
+		t.Fatalf("CreateBufferUnsafe error %v", err)
+	}
+	hostMem.Free()
+	if hostMem.pending == nil {
+		t.Fatalf("Free released memory used by a live buffer")
+	}
+	buffer.Release()
+}
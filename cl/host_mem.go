@@ -2,6 +2,7 @@ package cl
 
 import (
 	"errors"
+	"reflect"
 	"unsafe"
 )
 
@@ -10,7 +11,7 @@ var (
 	ErrHostMemWasEmpty    = errors.New("HostMem slice was empty")
 	ErrHostMemWasNil      = errors.New("HostMem slice was nil")
 	ErrHostMemInvalidData = errors.New("HostMem data was invalid")
-	ErrInvalidNumType     = errors.New("NewNumType invalid NumType")
+	ErrInvalidNumType     = errors.New("NewHostMem invalid NumType")
 )
 
 // HostMem ..
@@ -21,6 +22,213 @@ type HostMem interface {
 	SizeofT() uintptr
 }
 
+// NewHostMem returns the HostMem for a slice of any supported number type
+// or of Go array vectors (see SliceVector), or the HostMem itself if data
+// already is one.
+func NewHostMem(data interface{}) (HostMem, error) {
+	var h HostMem
+	switch d := data.(type) {
+	case nil:
+		return nil, ErrHostMemWasNil
+	case HostMem:
+		h = d
+	case []int8:
+		h = SliceI8(d)
+	case []uint8:
+		h = SliceU8(d)
+	case []int16:
+		h = SliceI16(d)
+	case []uint16:
+		h = SliceU16(d)
+	case []int32:
+		h = SliceI32(d)
+	case []uint32:
+		h = SliceU32(d)
+	case []float32:
+		h = SliceF32(d)
+	case []int64:
+		h = SliceI64(d)
+	case []uint64:
+		h = SliceU64(d)
+	case []float64:
+		h = SliceF64(d)
+	case []uint:
+		h = SliceUint(d)
+	case []Half:
+		h = SliceF16(d)
+	default:
+		v, ok := newSliceVector(data)
+		if !ok {
+			return nil, ErrInvalidNumType
+		}
+		h = v
+	}
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Slice, reflect.Ptr:
+		if v.IsNil() {
+			return nil, ErrHostMemWasNil
+		}
+	}
+	if h.Len() == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	return h, nil
+}
+
+// hostMemSize is the size in bytes of the data of h.
+func hostMemSize(h HostMem) int {
+	return h.Len() * int(h.SizeofT())
+}
+
+// checkHostMem returns an error if h has no data a pointer can be taken to.
+func checkHostMem(h HostMem) error {
+	if h == nil {
+		return ErrHostMemWasNil
+	}
+	if h.Len() == 0 {
+		return ErrHostMemWasEmpty
+	}
+	return nil
+}
+
+// SliceI8 ..
+type SliceI8 []int8
+
+// Ptr ..
+func (h SliceI8) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceI8) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceI8) NumType() NumTyped {
+	return Int8
+}
+
+// SizeofT ..
+func (h SliceI8) SizeofT() uintptr {
+	return size8
+}
+
+// SliceU8 ..
+type SliceU8 []uint8
+
+// Ptr ..
+func (h SliceU8) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceU8) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceU8) NumType() NumTyped {
+	return Uint8
+}
+
+// SizeofT ..
+func (h SliceU8) SizeofT() uintptr {
+	return size8
+}
+
+// SliceI16 ..
+type SliceI16 []int16
+
+// Ptr ..
+func (h SliceI16) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceI16) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceI16) NumType() NumTyped {
+	return Int16
+}
+
+// SizeofT ..
+func (h SliceI16) SizeofT() uintptr {
+	return size16
+}
+
+// SliceU16 ..
+type SliceU16 []uint16
+
+// Ptr ..
+func (h SliceU16) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceU16) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceU16) NumType() NumTyped {
+	return Uint16
+}
+
+// SizeofT ..
+func (h SliceU16) SizeofT() uintptr {
+	return size16
+}
+
+// SliceI32 ..
+type SliceI32 []int32
+
+// Ptr ..
+func (h SliceI32) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceI32) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceI32) NumType() NumTyped {
+	return Int32
+}
+
+// SizeofT ..
+func (h SliceI32) SizeofT() uintptr {
+	return size32
+}
+
+// SliceU32 ..
+type SliceU32 []uint32
+
+// Ptr ..
+func (h SliceU32) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceU32) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceU32) NumType() NumTyped {
+	return Uint32
+}
+
+// SizeofT ..
+func (h SliceU32) SizeofT() uintptr {
+	return size32
+}
+
 // SliceF32 ..
 type SliceF32 []float32
 
@@ -39,9 +247,99 @@ func (h SliceF32) NumType() NumTyped {
 	return Float32
 }
 
-const sizeOfF32 = unsafe.Sizeof(float32(0))
-
 // SizeofT ..
 func (h SliceF32) SizeofT() uintptr {
-	return uintptr(4)
+	return size32
+}
+
+// SliceI64 ..
+type SliceI64 []int64
+
+// Ptr ..
+func (h SliceI64) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceI64) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceI64) NumType() NumTyped {
+	return Int64
+}
+
+// SizeofT ..
+func (h SliceI64) SizeofT() uintptr {
+	return size64
+}
+
+// SliceU64 ..
+type SliceU64 []uint64
+
+// Ptr ..
+func (h SliceU64) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceU64) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceU64) NumType() NumTyped {
+	return Uint64
+}
+
+// SizeofT ..
+func (h SliceU64) SizeofT() uintptr {
+	return size64
+}
+
+// SliceF64 ..
+type SliceF64 []float64
+
+// Ptr ..
+func (h SliceF64) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceF64) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceF64) NumType() NumTyped {
+	return Float64
+}
+
+// SizeofT ..
+func (h SliceF64) SizeofT() uintptr {
+	return size64
+}
+
+// SliceUint ..
+type SliceUint []uint
+
+// Ptr ..
+func (h SliceUint) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&h[0])
+}
+
+// Len ..
+func (h SliceUint) Len() int {
+	return len(h)
+}
+
+// NumType ..
+func (h SliceUint) NumType() NumTyped {
+	return Uint
+}
+
+// SizeofT ..
+func (h SliceUint) SizeofT() uintptr {
+	return sizeT
 }
@@ -1,6 +1,7 @@
 package cl
 
 import (
+	"reflect"
 	"testing"
 	"unsafe"
 )
@@ -32,3 +33,90 @@ func TestHostMemForSliceFloat32(t *testing.T) {
 		t.Fatalf("SliceF32 was not NumTyped as PrimitiveType Float32")
 	}
 }
+
+func TestNewHostMemForEveryPrimitiveType(t *testing.T) {
+	cases := []struct {
+		data     interface{}
+		numType  PrimitiveType
+		expected HostMem
+	}{
+		{[]int8{1}, Int8, SliceI8{1}},
+		{[]uint8{1}, Uint8, SliceU8{1}},
+		{[]int16{1}, Int16, SliceI16{1}},
+		{[]uint16{1}, Uint16, SliceU16{1}},
+		{[]int32{1}, Int32, SliceI32{1}},
+		{[]uint32{1}, Uint32, SliceU32{1}},
+		{[]float32{1}, Float32, SliceF32{1}},
+		{[]int64{1}, Int64, SliceI64{1}},
+		{[]uint64{1}, Uint64, SliceU64{1}},
+		{[]float64{1}, Float64, SliceF64{1}},
+		{[]uint{1}, Uint, SliceUint{1}},
+	}
+	for _, c := range cases {
+		hostMem, err := NewHostMem(c.data)
+		if err != nil {
+			t.Fatalf("NewHostMem(%T) error %v", c.data, err)
+		}
+		if reflect.TypeOf(hostMem) != reflect.TypeOf(c.expected) {
+			t.Fatalf("NewHostMem(%T) expected %T got %T", c.data, c.expected, hostMem)
+		}
+		if hostMem.NumType().(PrimitiveType) != c.numType {
+			t.Fatalf("NewHostMem(%T) expected NumType %v got %v", c.data, c.numType, hostMem.NumType())
+		}
+		if hostMem.SizeofT() != c.numType.SizeofT() {
+			t.Fatalf("NewHostMem(%T) expected SizeofT %d got %d", c.data, c.numType.SizeofT(), hostMem.SizeofT())
+		}
+		if hostMem.Ptr() != reflect.ValueOf(c.data).Index(0).Addr().UnsafePointer() {
+			t.Fatalf("NewHostMem(%T) did not point at the slice data", c.data)
+		}
+	}
+}
+
+func TestNewHostMemErrors(t *testing.T) {
+	if _, err := NewHostMem([]string{"a"}); err != ErrInvalidNumType {
+		t.Fatalf("NewHostMem([]string) expected ErrInvalidNumType got %v", err)
+	}
+	if _, err := NewHostMem([]int32{}); err != ErrHostMemWasEmpty {
+		t.Fatalf("NewHostMem of empty slice expected ErrHostMemWasEmpty got %v", err)
+	}
+	var nilSlice []float64
+	if _, err := NewHostMem(nilSlice); err != ErrHostMemWasNil {
+		t.Fatalf("NewHostMem of nil slice expected ErrHostMemWasNil got %v", err)
+	}
+	sliceF32 := SliceF32{1, 2}
+	if hostMem, err := NewHostMem(sliceF32); err != nil || hostMem.Len() != 2 {
+		t.Fatalf("NewHostMem(SliceF32) expected itself got %v %v", hostMem, err)
+	}
+	if _, err := NewHostMem(SliceF32{}); err != ErrHostMemWasEmpty {
+		t.Fatalf("NewHostMem of empty SliceF32 expected ErrHostMemWasEmpty got %v", err)
+	}
+	if _, err := NewHostMem(SliceF32(nil)); err != ErrHostMemWasNil {
+		t.Fatalf("NewHostMem of nil SliceF32 expected ErrHostMemWasNil got %v", err)
+	}
+	if _, err := NewHostMem((*AlignedHostMem)(nil)); err != ErrHostMemWasNil {
+		t.Fatalf("NewHostMem of nil *AlignedHostMem expected ErrHostMemWasNil got %v", err)
+	}
+}
+
+func TestBufferHostMemRoundTripWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	input := SliceI16{1, -2, 3, -4}
+	buffer, err := context.CreateBufferHostMem(MemReadWrite|MemCopyHostPtr, input)
+	if err != nil {
+		t.Fatalf("CreateBufferHostMem error %v", err)
+	}
+	output := make(SliceI16, len(input))
+	if _, err := queue.EnqueueReadBufferHostMem(buffer, true, 0, output, nil); err != nil {
+		t.Fatalf("EnqueueReadBufferHostMem error %v", err)
+	}
+	if !reflect.DeepEqual(input, output) {
+		t.Fatalf("EnqueueReadBufferHostMem expected %v got %v", input, output)
+	}
+}
@@ -18,6 +18,18 @@ import (
 	"unsafe"
 )
 
+// ImageInfo is the information reported by clGetImageInfo.
+type ImageInfo struct {
+	Format       ImageFormat
+	ElementSize  int
+	RowPitch     int
+	SlicePitch   int
+	Width        int
+	Height       int
+	Depth        int
+	ArraySize    int
+	NumMipLevels int
+}
 
 // CreateImage ..
 func (ctx *Context) CreateImage(flags MemFlag, imageFormat ImageFormat, imageDesc ImageDescription, data []byte) (*MemObject, error) {
@@ -27,15 +39,20 @@ func (ctx *Context) CreateImage(flags MemFlag, imageFormat ImageFormat, imageDes
 	if data != nil {
 		dataPtr = unsafe.Pointer(&data[0])
 	}
+	hostPtr := newHostPtrRef(flags, dataPtr)
 	var err C.cl_int
 	clBuffer := C.clCreateImage(ctx.clContext, C.cl_mem_flags(flags), &format, &desc, dataPtr, &err)
 	if err != C.CL_SUCCESS {
+		hostPtr.release()
 		return nil, toError(err)
 	}
 	if clBuffer == nil {
+		hostPtr.release()
 		return nil, ErrUnknown
 	}
-	return newMemObject(clBuffer, len(data)), nil
+	memObject := newMemObject(clBuffer, len(data))
+	memObject.keepHostPtr(hostPtr)
+	return memObject, nil
 }
 
 // CreateImageSimple ..
@@ -90,3 +107,49 @@ func (ctx *Context) CreateImageFromImage(flags MemFlag, img image.Image) (*MemOb
 	}
 	return ctx.CreateImageSimple(flags, w, h, ChannelOrderRGBA, ChannelDataTypeUNormInt8, data)
 }
+
+func (b *MemObject) getImageInfoSize(param C.cl_image_info) (int, error) {
+	var val C.size_t
+	if err := C.clGetImageInfo(b.clMem, param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return int(val), nil
+}
+
+// ImageInfo returns the format and dimensions of an image memory object.
+func (b *MemObject) ImageInfo() (ImageInfo, error) {
+	var info ImageInfo
+	var format C.cl_image_format
+	if err := C.clGetImageInfo(b.clMem, C.CL_IMAGE_FORMAT, C.size_t(unsafe.Sizeof(format)), unsafe.Pointer(&format), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	info.Format = ImageFormat{
+		ChannelOrder:    ChannelOrder(format.image_channel_order),
+		ChannelDataType: ChannelDataType(format.image_channel_data_type),
+	}
+	sizes := []struct {
+		param C.cl_image_info
+		val   *int
+	}{
+		{C.CL_IMAGE_ELEMENT_SIZE, &info.ElementSize},
+		{C.CL_IMAGE_ROW_PITCH, &info.RowPitch},
+		{C.CL_IMAGE_SLICE_PITCH, &info.SlicePitch},
+		{C.CL_IMAGE_WIDTH, &info.Width},
+		{C.CL_IMAGE_HEIGHT, &info.Height},
+		{C.CL_IMAGE_DEPTH, &info.Depth},
+		{C.CL_IMAGE_ARRAY_SIZE, &info.ArraySize},
+	}
+	for _, s := range sizes {
+		val, err := b.getImageInfoSize(s.param)
+		if err != nil {
+			return info, err
+		}
+		*s.val = val
+	}
+	var mipLevels C.cl_uint
+	if err := C.clGetImageInfo(b.clMem, C.CL_IMAGE_NUM_MIP_LEVELS, C.size_t(unsafe.Sizeof(mipLevels)), unsafe.Pointer(&mipLevels), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	info.NumMipLevels = int(mipLevels)
+	return info, nil
+}
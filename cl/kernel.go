@@ -31,11 +31,28 @@ func (e ErrUnsupportedArgumentType) Error() string {
 type Kernel struct {
 	clKernel C.cl_kernel
 	name     string
+	args     []KernelArg // set by EnableArgChecks
+}
+
+// KernelArg is the declaration of a kernel argument, see Kernel.Args.
+// TypeName is the type as written in the source, e.g. "float*" or "image2d_t".
+type KernelArg struct {
+	Index            int
+	Name             string
+	TypeName         string
+	AddressQualifier KernelArgAddressQualifier
+	AccessQualifier  KernelArgAccessQualifier
+	TypeQualifier    KernelArgTypeQualifier
 }
 
 // LocalBuffer ..
 type LocalBuffer int
 
+// memObjectArg is implemented by typed wrappers of a MemObject such as Buffer.
+type memObjectArg interface {
+	MemObject() *MemObject
+}
+
 func releaseKernel(k *Kernel) {
 	if k.clKernel != nil {
 		C.clReleaseKernel(k.clKernel)
@@ -58,14 +75,27 @@ func (k *Kernel) SetArgs(args ...interface{}) error {
 	return nil
 }
 
-// SetArg sets the given arg at the given index on the Kernel
+// SetArg sets the given arg at the given index on the Kernel. After
+// EnableArgChecks the arg is first checked against the declared argument.
 func (k *Kernel) SetArg(index int, arg interface{}) error {
+	if k.args != nil {
+		if err := k.checkArg(index, arg); err != nil {
+			return err
+		}
+	}
 	switch val := arg.(type) {
 	case *MemObject:
 		return k.SetArgBuffer(index, val)
 	case LocalBuffer:
 		return k.SetArgLocal(index, int(val))
+	case *Sampler:
+		return k.SetArgSampler(index, val)
+	case memObjectArg:
+		return k.SetArgBuffer(index, val.MemObject())
 	default:
+		if isStructArg(arg) {
+			return k.SetArgStruct(index, arg)
+		}
 		return k.SetArgNumber(index, arg)
 	}
 }
@@ -75,12 +105,27 @@ func (k *Kernel) SetArgBuffer(index int, buffer *MemObject) error {
 	return k.SetArgUnsafe(index, int(unsafe.Sizeof(buffer.clMem)), unsafe.Pointer(&buffer.clMem))
 }
 
+// SetArgSampler ..
+func (k *Kernel) SetArgSampler(index int, sampler *Sampler) error {
+	return k.SetArgUnsafe(index, int(unsafe.Sizeof(sampler.clSampler)), unsafe.Pointer(&sampler.clSampler))
+}
+
+// SetArgStruct sets a struct argument in its OpenCL C layout, see StructType.
+func (k *Kernel) SetArgStruct(index int, arg interface{}) error {
+	st, err := NewStructType(arg)
+	if err != nil {
+		return err
+	}
+	data := st.Marshal(arg)
+	return k.SetArgUnsafe(index, len(data), unsafe.Pointer(&data[0]))
+}
+
 // SetArgLocal ..
 func (k *Kernel) SetArgLocal(index int, size int) error {
 	return k.SetArgUnsafe(index, size, nil)
 }
 
-// SetArgNumber ..
+// SetArgNumber sets a scalar or, given a Go array like [4]float32, a vector argument.
 func (k *Kernel) SetArgNumber(index int, arg interface{}) error {
 	switch val := arg.(type) {
 	case uint8:
@@ -105,7 +150,12 @@ func (k *Kernel) SetArgNumber(index int, arg interface{}) error {
 		return k.SetArgUnsafe(index, int(unsafe.Sizeof(val)), unsafe.Pointer(&val))
 	case uint:
 		return k.SetArgUnsafe(index, int(unsafe.Sizeof(val)), unsafe.Pointer(&val))
+	case Half:
+		return k.SetArgUnsafe(index, int(unsafe.Sizeof(val)), unsafe.Pointer(&val))
 	default:
+		if data, ok := vectorArgBytes(arg); ok {
+			return k.SetArgUnsafe(index, len(data), unsafe.Pointer(&data[0]))
+		}
 		return ErrUnsupportedArgumentType{Index: index, Value: arg}
 	}
 }
@@ -129,6 +179,17 @@ func (k *Kernel) WorkGroupSize(device *Device) (int, error) {
 	return int(size), toError(err)
 }
 
+// Name is the name of the kernel function.
+func (k *Kernel) Name() string {
+	return k.name
+}
+
+func (k *Kernel) getInfoString(param C.cl_kernel_info) (string, error) {
+	return queryString(func(size C.size_t, value unsafe.Pointer, sizeRet *C.size_t) C.cl_int {
+		return C.clGetKernelInfo(k.clKernel, param, size, value, sizeRet)
+	})
+}
+
 // NumArgs is the number of args for a Kernel
 func (k *Kernel) NumArgs() (int, error) {
 	var num C.cl_uint
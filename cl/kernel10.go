@@ -6,3 +6,23 @@ package cl
 func (k *Kernel) ArgName(index int) (string, error) {
 	return "", ErrUnsupported
 }
+
+// Arg is not supported by OpenCL 1.0
+func (k *Kernel) Arg(index int) (KernelArg, error) {
+	return KernelArg{}, ErrUnsupported
+}
+
+// EnableArgChecks is not supported by OpenCL 1.0
+func (k *Kernel) EnableArgChecks() error {
+	return ErrUnsupported
+}
+
+// OpenCL 1.0 has no kernel argument info to check against.
+func (k *Kernel) checkArg(index int, arg interface{}) error {
+	return nil
+}
+
+// Args is not supported by OpenCL 1.0
+func (k *Kernel) Args() ([]KernelArg, error) {
+	return nil, ErrUnsupported
+}
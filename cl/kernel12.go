@@ -13,14 +13,262 @@ package cl
 #endif
 */
 import "C"
-import "unsafe"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+func (k *Kernel) getArgInfoString(index int, param C.cl_kernel_arg_info) (string, error) {
+	return queryString(func(size C.size_t, value unsafe.Pointer, sizeRet *C.size_t) C.cl_int {
+		return C.clGetKernelArgInfo(k.clKernel, C.cl_uint(index), param, size, value, sizeRet)
+	})
+}
+
+func (k *Kernel) getArgInfoUint(index int, param C.cl_kernel_arg_info) (int, error) {
+	var val C.cl_uint
+	if err := C.clGetKernelArgInfo(k.clKernel, C.cl_uint(index), param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return int(val), nil
+}
 
 // ArgName is the argument name in source code of the argument at the given index.
 func (k *Kernel) ArgName(index int) (string, error) {
-	var strC [1024]byte
-	var strN C.size_t
-	if err := C.clGetKernelArgInfo(k.clKernel, C.cl_uint(index), C.CL_KERNEL_ARG_NAME, 1024, unsafe.Pointer(&strC[0]), &strN); err != C.CL_SUCCESS {
-		return "", toError(err)
+	return k.getArgInfoString(index, C.CL_KERNEL_ARG_NAME)
+}
+
+// Arg returns the declaration of the argument at the given index. It fails
+// with ErrKernelArgInfoNotAvailable unless the program was built with the
+// -cl-kernel-arg-info option or from source on some implementations.
+func (k *Kernel) Arg(index int) (KernelArg, error) {
+	arg := KernelArg{Index: index}
+	address, err := k.getArgInfoUint(index, C.CL_KERNEL_ARG_ADDRESS_QUALIFIER)
+	if err != nil {
+		return arg, err
+	}
+	access, err := k.getArgInfoUint(index, C.CL_KERNEL_ARG_ACCESS_QUALIFIER)
+	if err != nil {
+		return arg, err
+	}
+	var typeQualifier C.cl_kernel_arg_type_qualifier
+	if err := C.clGetKernelArgInfo(k.clKernel, C.cl_uint(index), C.CL_KERNEL_ARG_TYPE_QUALIFIER, C.size_t(unsafe.Sizeof(typeQualifier)), unsafe.Pointer(&typeQualifier), nil); err != C.CL_SUCCESS {
+		return arg, toError(err)
+	}
+	if arg.TypeName, err = k.getArgInfoString(index, C.CL_KERNEL_ARG_TYPE_NAME); err != nil {
+		return arg, err
+	}
+	if arg.Name, err = k.getArgInfoString(index, C.CL_KERNEL_ARG_NAME); err != nil {
+		return arg, err
+	}
+	arg.AddressQualifier = KernelArgAddressQualifier(address)
+	arg.AccessQualifier = KernelArgAccessQualifier(access)
+	arg.TypeQualifier = KernelArgTypeQualifier(typeQualifier)
+	return arg, nil
+}
+
+// Args returns the declarations of all arguments of the kernel, see Arg.
+func (k *Kernel) Args() ([]KernelArg, error) {
+	numArgs, err := k.NumArgs()
+	if err != nil {
+		return nil, err
+	}
+	args := make([]KernelArg, numArgs)
+	for i := range args {
+		if args[i], err = k.Arg(i); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// ErrKernelArgMismatch is returned by SetArg, once EnableArgChecks has been
+// called, when a Go value does not match the declared kernel argument.
+type ErrKernelArgMismatch struct {
+	Kernel   string
+	Index    int
+	Name     string
+	Declared string // the declaration, e.g. "__global float*"
+	Value    interface{}
+}
+
+func (e ErrKernelArgMismatch) Error() string {
+	return fmt.Sprintf("cl: argument %d (%s) of kernel %s is declared %s but got %T", e.Index, e.Name, e.Kernel, e.Declared, e.Value)
+}
+
+// EnableArgChecks makes SetArg and SetArgs check every argument against its
+// declaration before setting it: the kind and size of scalars and vectors,
+// the address space and scalar element type of buffers, structs by name,
+// images and samplers. Buffers created with MemWriteOnly are rejected for
+// __constant arguments. Arguments whose declared type is a typedef of a
+// scalar are only checked to be scalars. The other SetArg methods are not
+// checked.
+//
+// It fails with ErrKernelArgInfoNotAvailable unless the program was built
+// with the -cl-kernel-arg-info option.
+func (k *Kernel) EnableArgChecks() error {
+	args, err := k.Args()
+	if err != nil {
+		return err
+	}
+	if args == nil {
+		args = []KernelArg{}
+	}
+	k.args = args
+	return nil
+}
+
+func (k *Kernel) checkArg(index int, arg interface{}) error {
+	if index < 0 || index >= len(k.args) {
+		return ErrInvalidArgIndex
+	}
+	if !kernelArgMatches(k.args[index], arg) {
+		decl := k.args[index]
+		return ErrKernelArgMismatch{Kernel: k.name, Index: index, Name: decl.Name, Declared: declaredKernelArg(decl), Value: arg}
+	}
+	return nil
+}
+
+// declaredKernelArg formats the declaration of a kernel argument.
+func declaredKernelArg(decl KernelArg) string {
+	if !strings.HasSuffix(decl.TypeName, "*") {
+		return decl.TypeName
+	}
+	switch decl.AddressQualifier {
+	case KernelArgAddressGlobal:
+		return "__global " + decl.TypeName
+	case KernelArgAddressLocal:
+		return "__local " + decl.TypeName
+	case KernelArgAddressConstant:
+		return "__constant " + decl.TypeName
+	}
+	return decl.TypeName
+}
+
+// kernelArgQualifiers are the words some implementations include in the
+// reported type name that are not part of the type itself.
+var kernelArgQualifiers = map[string]bool{
+	"const": true, "volatile": true, "restrict": true, "struct": true,
+	"__global": true, "global": true, "__local": true, "local": true,
+	"__constant": true, "constant": true, "__private": true, "private": true,
+	"__read_only": true, "read_only": true, "__write_only": true, "write_only": true,
+	"__read_write": true, "read_write": true,
+}
+
+// baseTypeName returns the declared type name without qualifiers and pointer.
+func baseTypeName(typeName string) (string, bool) {
+	pointer := strings.HasSuffix(typeName, "*")
+	var words []string
+	for _, w := range strings.Fields(strings.TrimRight(typeName, "* ")) {
+		if !kernelArgQualifiers[w] {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " "), pointer
+}
+
+// primitiveTypeOf returns the PrimitiveType of a scalar or Go array vector.
+func primitiveTypeOf(arg interface{}) (PrimitiveType, bool) {
+	switch arg.(type) {
+	case int8:
+		return Int8, true
+	case uint8:
+		return Uint8, true
+	case int16:
+		return Int16, true
+	case uint16:
+		return Uint16, true
+	case int32:
+		return Int32, true
+	case uint32:
+		return Uint32, true
+	case float32:
+		return Float32, true
+	case int64:
+		return Int64, true
+	case uint64:
+		return Uint64, true
+	case float64:
+		return Float64, true
+	case uint:
+		return Uint, true
+	case Half:
+		return Float16, true
+	}
+	if arg == nil {
+		return 0, false
+	}
+	return vectorTypeOf(reflect.TypeOf(arg))
+}
+
+// elementTypeMatches reports whether the element type of a typed buffer
+// matches the declared pointee type name. Only the scalar kinds are compared,
+// so a buffer of float32 can be passed as float4* and the other way around.
+func elementTypeMatches(numType NumTyped, base string) bool {
+	if base == "void" {
+		return true
+	}
+	switch t := numType.(type) {
+	case PrimitiveType:
+		declared, err := ParsePrimitiveType(base)
+		return err != nil || declared.Scalar() == t.Scalar()
+	case *StructType:
+		return t.Name() == base
+	}
+	return true
+}
+
+func kernelArgMatches(decl KernelArg, arg interface{}) bool {
+	base, pointer := baseTypeName(decl.TypeName)
+	switch {
+	case strings.HasPrefix(base, "image"):
+		memObject, ok := arg.(*MemObject)
+		if !ok || memObject == nil {
+			return false
+		}
+		// a buffer where an image is declared is the common mistake
+		var memType C.cl_mem_object_type
+		if err := C.clGetMemObjectInfo(memObject.clMem, C.CL_MEM_TYPE, C.size_t(unsafe.Sizeof(memType)), unsafe.Pointer(&memType), nil); err != C.CL_SUCCESS {
+			return true
+		}
+		return MemObjectType(memType) != MemObjectTypeBuffer
+	case base == "sampler_t":
+		_, ok := arg.(*Sampler)
+		return ok
+	case pointer && decl.AddressQualifier == KernelArgAddressLocal:
+		_, ok := arg.(LocalBuffer)
+		return ok
+	case pointer:
+		var memObject *MemObject
+		switch val := arg.(type) {
+		case *MemObject:
+			memObject = val
+		case memObjectArg:
+			if typed, ok := val.(interface{ NumType() NumTyped }); ok && !elementTypeMatches(typed.NumType(), base) {
+				return false
+			}
+			memObject = val.MemObject()
+		default:
+			return false
+		}
+		// kernels can't write to __constant memory, so a write-only buffer
+		// there can't be meant
+		return decl.AddressQualifier != KernelArgAddressConstant || memObject == nil || memObject.flags&MemWriteOnly == 0
+	}
+	if isStructArg(arg) {
+		st, err := NewStructType(arg)
+		return err == nil && st.Name() == base
+	}
+	t, ok := primitiveTypeOf(arg)
+	if !ok {
+		return false
+	}
+	declared, err := ParsePrimitiveType(base)
+	if err != nil {
+		// a typedef; only check that a scalar or vector was given
+		return true
 	}
-	return string(strC[:strN]), nil
+	return declared == t
 }
@@ -0,0 +1,164 @@
+// +build !cl10
+
+package cl
+
+import "testing"
+
+func TestKernelArgQualifierStrings(t *testing.T) {
+	if s := (KernelArgTypeConst | KernelArgTypeVolatile).String(); s != "Const|Volatile" {
+		t.Fatalf("KernelArgTypeQualifier.String() expected Const|Volatile got %q", s)
+	}
+	if s := KernelArgTypeQualifier(0).String(); s != "None" {
+		t.Fatalf("KernelArgTypeQualifier(0).String() expected None got %q", s)
+	}
+	if s := KernelArgAddressGlobal.String(); s != "Global" {
+		t.Fatalf("KernelArgAddressGlobal.String() expected Global got %q", s)
+	}
+	if s := KernelArgAccessNone.String(); s != "None" {
+		t.Fatalf("KernelArgAccessNone.String() expected None got %q", s)
+	}
+}
+
+func TestKernelArgsWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, "-cl-kernel-arg-info"); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	kernel, err := program.CreateKernel("square")
+	if err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+	args, err := kernel.Args()
+	if err != nil {
+		t.Fatalf("Args error %v", err)
+	}
+	expected := []KernelArg{
+		{Index: 0, Name: "input", TypeName: "float*", AddressQualifier: KernelArgAddressGlobal, AccessQualifier: KernelArgAccessNone},
+		{Index: 1, Name: "output", TypeName: "float*", AddressQualifier: KernelArgAddressGlobal, AccessQualifier: KernelArgAccessNone},
+		{Index: 2, Name: "count", TypeName: "uint", AddressQualifier: KernelArgAddressPrivate, AccessQualifier: KernelArgAccessNone},
+	}
+	if len(args) != len(expected) {
+		t.Fatalf("Args expected %d args got %+v", len(expected), args)
+	}
+	for i := range expected {
+		args[i].TypeQualifier = 0
+		if args[i] != expected[i] {
+			t.Fatalf("Args[%d] expected %+v got %+v", i, expected[i], args[i])
+		}
+	}
+}
+
+func TestKernelArgMatches(t *testing.T) {
+	global := KernelArg{TypeName: "float*", AddressQualifier: KernelArgAddressGlobal}
+	local := KernelArg{TypeName: "float*", AddressQualifier: KernelArgAddressLocal}
+	constant := KernelArg{TypeName: "const int *", AddressQualifier: KernelArgAddressConstant}
+	structs := KernelArg{TypeName: "struct testInner*", AddressQualifier: KernelArgAddressGlobal}
+	scalar := KernelArg{TypeName: "int", AddressQualifier: KernelArgAddressPrivate}
+	vector := KernelArg{TypeName: "float4", AddressQualifier: KernelArgAddressPrivate}
+	typedef := KernelArg{TypeName: "my_int_t", AddressQualifier: KernelArgAddressPrivate}
+	sampler := KernelArg{TypeName: "sampler_t", AddressQualifier: KernelArgAddressPrivate}
+	image := KernelArg{TypeName: "image2d_t", AddressQualifier: KernelArgAddressGlobal}
+	global4 := KernelArg{TypeName: "float4*", AddressQualifier: KernelArgAddressGlobal}
+	writeOnly := &MemObject{flags: MemWriteOnly}
+	cases := []struct {
+		decl    KernelArg
+		arg     interface{}
+		matches bool
+	}{
+		{global, &MemObject{}, true},
+		{global, &Buffer[float32]{}, true},
+		{global, &Buffer[int32]{}, false},
+		{global, LocalBuffer(16), false},
+		{global, float32(1), false},
+		{local, LocalBuffer(16), true},
+		{local, &MemObject{}, false},
+		{constant, &Buffer[int32]{}, true},
+		{constant, &Buffer[uint32]{}, false},
+		{constant, &MemObject{flags: MemReadOnly}, true},
+		{constant, writeOnly, false},
+		{constant, &Buffer[int32]{memObject: writeOnly}, false},
+		{global, writeOnly, true},
+		{global4, &Buffer[float32]{}, true},
+		{global4, &Buffer[int32]{}, false},
+		{structs, &StructBuffer[testInner]{structType: &StructType{name: "testInner"}}, true},
+		{structs, &StructBuffer[testParticle]{structType: &StructType{name: "testParticle"}}, false},
+		{scalar, int32(1), true},
+		{scalar, uint32(1), false},
+		{scalar, float64(1), false},
+		{scalar, int64(1), false},
+		{scalar, &MemObject{}, false},
+		{vector, [4]float32{}, true},
+		{vector, [3]float32{}, false},
+		{vector, float32(1), false},
+		{typedef, int16(1), true},
+		{typedef, &MemObject{}, false},
+		{sampler, &Sampler{}, true},
+		{sampler, &MemObject{}, false},
+		{image, (*MemObject)(nil), false},
+		{image, LocalBuffer(1), false},
+		{KernelArg{TypeName: "testInner"}, testInner{}, true},
+		{KernelArg{TypeName: "testInner"}, testParticle{}, false},
+	}
+	for i, c := range cases {
+		if matches := kernelArgMatches(c.decl, c.arg); matches != c.matches {
+			t.Fatalf("case %d: kernelArgMatches(%q, %T) expected %v got %v", i, c.decl.TypeName, c.arg, c.matches, matches)
+		}
+	}
+}
+
+func TestKernelCheckArgReportsMismatch(t *testing.T) {
+	k := &Kernel{name: "square", args: []KernelArg{
+		{Index: 0, Name: "input", TypeName: "float*", AddressQualifier: KernelArgAddressGlobal},
+		{Index: 1, Name: "count", TypeName: "uint", AddressQualifier: KernelArgAddressPrivate},
+	}}
+	err := k.SetArg(1, float64(3))
+	mismatch, ok := err.(ErrKernelArgMismatch)
+	if !ok || mismatch.Index != 1 || mismatch.Name != "count" {
+		t.Fatalf("SetArg expected ErrKernelArgMismatch for count got %v", err)
+	}
+	expected := "cl: argument 1 (count) of kernel square is declared uint but got float64"
+	if err.Error() != expected {
+		t.Fatalf("ErrKernelArgMismatch.Error() expected %q got %q", expected, err.Error())
+	}
+	err = k.SetArg(0, LocalBuffer(4))
+	if mismatch, ok := err.(ErrKernelArgMismatch); !ok || mismatch.Declared != "__global float*" {
+		t.Fatalf("SetArg expected ErrKernelArgMismatch declared __global float* got %v", err)
+	}
+	if err := k.SetArg(2, int32(1)); err != ErrInvalidArgIndex {
+		t.Fatalf("SetArg past the last argument expected ErrInvalidArgIndex got %v", err)
+	}
+}
+
+func TestEnableArgChecksWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, "-cl-kernel-arg-info"); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	kernel, err := program.CreateKernel("square")
+	if err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+	if err := kernel.EnableArgChecks(); err != nil {
+		t.Fatalf("EnableArgChecks error %v", err)
+	}
+	if err := kernel.SetArg(2, uint32(4)); err != nil {
+		t.Fatalf("SetArg(uint32) error %v", err)
+	}
+	if _, ok := kernel.SetArg(2, float32(4)).(ErrKernelArgMismatch); !ok {
+		t.Fatalf("SetArg(float32) for uint argument did not fail with ErrKernelArgMismatch")
+	}
+}
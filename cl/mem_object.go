@@ -1,6 +1,5 @@
 package cl
 
-
 /*
 #cgo CFLAGS: -I CL -w
 #cgo !darwin LDFLAGS: -lOpenCL
@@ -14,12 +13,18 @@ package cl
 */
 import "C"
 
-import "runtime"
+import (
+	"runtime"
+	"unsafe"
+)
 
 // MemObject ..
 type MemObject struct {
-	clMem C.cl_mem
-	size  int
+	clMem   C.cl_mem
+	size    int
+	flags   MemFlag // access flags the buffer was created with, if known
+	parent  *MemObject
+	hostPtr *hostPtrRef // only set when it can't be released on destruction
 }
 
 func releaseContext(c *Context) {
@@ -27,6 +32,10 @@ func releaseContext(c *Context) {
 		C.clReleaseContext(c.clContext)
 		c.clContext = nil
 	}
+	if c.notifyHandle != 0 {
+		unregisterCallback(c.notifyHandle)
+		c.notifyHandle = 0
+	}
 }
 
 func releaseMemObject(b *MemObject) {
@@ -34,6 +43,9 @@ func releaseMemObject(b *MemObject) {
 		C.clReleaseMemObject(b.clMem)
 		b.clMem = nil
 	}
+	b.parent = nil
+	b.hostPtr.release()
+	b.hostPtr = nil
 }
 
 func newMemObject(mo C.cl_mem, size int) *MemObject {
@@ -42,7 +54,132 @@ func newMemObject(mo C.cl_mem, size int) *MemObject {
 	return memObject
 }
 
+// hostPtrRef holds on to the host memory of a MemUseHostPtr memory object.
+// Go memory is pinned, as the driver keeps using it after the cgo call that
+// created the memory object has returned, and an AlignedHostMem is kept from
+// being freed.
+type hostPtrRef struct {
+	pinner  runtime.Pinner
+	hostMem *AlignedHostMem
+}
+
+// newHostPtrRef returns the hostPtrRef for creating a memory object with
+// flags from ptr, or nil if the memory is not used by the memory object. It
+// must be called before the memory object is created and released if that
+// fails.
+func newHostPtrRef(flags MemFlag, ptr unsafe.Pointer) *hostPtrRef {
+	if flags&MemUseHostPtr == 0 || ptr == nil {
+		return nil
+	}
+	ref := &hostPtrRef{hostMem: acquireAlignedHostMem(ptr)}
+	// a no-op for memory outside of the Go heap
+	ref.pinner.Pin(ptr)
+	return ref
+}
+
+// release is a no-op on nil.
+func (r *hostPtrRef) release() {
+	if r == nil {
+		return
+	}
+	r.pinner.Unpin()
+	r.hostMem.release()
+}
+
+// keepHostPtr ties ref to the memory object. The driver may keep using the
+// memory after the MemObject itself has been released, so on OpenCL 1.1 and
+// later ref is released by the driver's destructor callback. On OpenCL 1.0
+// it is only held for the life of the MemObject.
+func (b *MemObject) keepHostPtr(ref *hostPtrRef) {
+	if ref == nil {
+		return
+	}
+	if err := b.OnDestroy(ref.release); err != nil {
+		b.hostPtr = ref
+	}
+}
+
+// MemObjectInfo is the information reported by clGetMemObjectInfo.
+// AssociatedMemObject and Offset are only set for sub-buffers.
+type MemObjectInfo struct {
+	Type                MemObjectType
+	Flags               MemFlag
+	Size                int
+	HostPtr             unsafe.Pointer
+	MapCount            int
+	ReferenceCount      int
+	Context             *Context
+	AssociatedMemObject *MemObject
+	Offset              int
+}
+
 // Release ..
 func (b *MemObject) Release() {
 	releaseMemObject(b)
-}
\ No newline at end of file
+}
+
+// Size is the size in bytes the MemObject was created with.
+func (b *MemObject) Size() int {
+	return b.size
+}
+
+func (b *MemObject) getInfoUint(param C.cl_mem_info) (int, error) {
+	var val C.cl_uint
+	if err := C.clGetMemObjectInfo(b.clMem, param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return int(val), nil
+}
+
+func (b *MemObject) getInfoSize(param C.cl_mem_info) (int, error) {
+	var val C.size_t
+	if err := C.clGetMemObjectInfo(b.clMem, param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return int(val), nil
+}
+
+// Info returns the driver's view of the memory object.
+func (b *MemObject) Info() (MemObjectInfo, error) {
+	var info MemObjectInfo
+	var memType C.cl_mem_object_type
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_TYPE, C.size_t(unsafe.Sizeof(memType)), unsafe.Pointer(&memType), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	var flags C.cl_mem_flags
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_FLAGS, C.size_t(unsafe.Sizeof(flags)), unsafe.Pointer(&flags), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	var hostPtr unsafe.Pointer
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_HOST_PTR, C.size_t(unsafe.Sizeof(hostPtr)), unsafe.Pointer(&hostPtr), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	var clContext C.cl_context
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_CONTEXT, C.size_t(unsafe.Sizeof(clContext)), unsafe.Pointer(&clContext), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	size, err := b.getInfoSize(C.CL_MEM_SIZE)
+	if err != nil {
+		return info, err
+	}
+	mapCount, err := b.getInfoUint(C.CL_MEM_MAP_COUNT)
+	if err != nil {
+		return info, err
+	}
+	refCount, err := b.getInfoUint(C.CL_MEM_REFERENCE_COUNT)
+	if err != nil {
+		return info, err
+	}
+	context, err := newContextFromID(clContext)
+	if err != nil {
+		return info, err
+	}
+	info.Type = MemObjectType(memType)
+	info.Flags = MemFlag(flags)
+	info.Size = size
+	info.HostPtr = hostPtr
+	info.MapCount = mapCount
+	info.ReferenceCount = refCount
+	info.Context = context
+	return info, b.getSubBufferInfo(&info)
+}
@@ -0,0 +1,18 @@
+// +build cl10
+
+package cl
+
+// CreateSubBuffer is not supported by OpenCL 1.0
+func (b *MemObject) CreateSubBuffer(flags MemFlag, origin, size int) (*MemObject, error) {
+	return nil, ErrUnsupported
+}
+
+// OnDestroy is not supported by OpenCL 1.0
+func (b *MemObject) OnDestroy(fn func()) error {
+	return ErrUnsupported
+}
+
+// OpenCL 1.0 has no sub-buffers.
+func (b *MemObject) getSubBufferInfo(info *MemObjectInfo) error {
+	return nil
+}
@@ -0,0 +1,141 @@
+// +build !cl10
+
+package cl
+
+/*
+#cgo CFLAGS: -I CL -w
+#cgo !darwin LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdint.h>
+
+extern void goMemObjectNotify(cl_mem memobj, uintptr_t handle);
+
+static void CL_CALLBACK memObjectNotify(cl_mem memobj, void *user_data) {
+	goMemObjectNotify(memobj, (uintptr_t)user_data);
+}
+
+static cl_int setMemObjectDestructorCallback(cl_mem memobj, uintptr_t handle) {
+	return clSetMemObjectDestructorCallback(memobj, memObjectNotify, (void *)handle);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ErrMisalignedSubBuffer is returned by CreateSubBuffer when the requested
+// origin is not aligned to the MemBaseAddrAlign of any device in the
+// buffer's context.
+type ErrMisalignedSubBuffer struct {
+	Origin    int
+	Alignment int // in bytes
+}
+
+func (e ErrMisalignedSubBuffer) Error() string {
+	return fmt.Sprintf("cl: sub-buffer origin %d is not aligned to %d bytes", e.Origin, e.Alignment)
+}
+
+// Unwrap allows errors.Is(err, ErrMisalignedSubBufferOffset) to match.
+func (e ErrMisalignedSubBuffer) Unwrap() error {
+	return ErrMisalignedSubBufferOffset
+}
+
+// CreateSubBuffer creates a MemObject that is a view of the region of the
+// buffer starting at origin and spanning size bytes. The parent buffer is
+// kept alive for as long as the sub-buffer is referenced.
+func (b *MemObject) CreateSubBuffer(flags MemFlag, origin, size int) (*MemObject, error) {
+	region := C.cl_buffer_region{
+		origin: C.size_t(origin),
+		size:   C.size_t(size),
+	}
+	var err C.cl_int
+	clBuffer := C.clCreateSubBuffer(b.clMem, C.cl_mem_flags(flags), C.CL_BUFFER_CREATE_TYPE_REGION, unsafe.Pointer(&region), &err)
+	if err == C.CL_MISALIGNED_SUB_BUFFER_OFFSET {
+		return nil, ErrMisalignedSubBuffer{Origin: origin, Alignment: b.baseAddrAlign()}
+	}
+	if err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if clBuffer == nil {
+		return nil, ErrUnknown
+	}
+	subBuffer := newMemObject(clBuffer, size)
+	subBuffer.parent = b
+	// sub-buffers inherit the access flags of the parent unless given
+	subBuffer.flags = flags & memAccessFlags
+	if subBuffer.flags == 0 {
+		subBuffer.flags = b.flags
+	}
+	return subBuffer, nil
+}
+
+// OnDestroy registers fn to be called once the OpenCL implementation has
+// destroyed the memory object, after its last reference was released and
+// any commands using it have completed. Functions registered on the same
+// object are called in the reverse order of registration. fn is called from
+// a thread owned by the OpenCL implementation and must not block.
+func (b *MemObject) OnDestroy(fn func()) error {
+	handle := registerCallback(fn)
+	if err := toError(C.setMemObjectDestructorCallback(b.clMem, C.uintptr_t(handle))); err != nil {
+		unregisterCallback(handle)
+		return err
+	}
+	return nil
+}
+
+// baseAddrAlign returns the smallest MemBaseAddrAlign (converted from bits
+// to bytes) of the devices in the buffer's context, or 0 if it cannot be
+// determined.
+func (b *MemObject) baseAddrAlign() int {
+	var clContext C.cl_context
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_CONTEXT, C.size_t(unsafe.Sizeof(clContext)), unsafe.Pointer(&clContext), nil); err != C.CL_SUCCESS {
+		return 0
+	}
+	devices, err := contextDevices(clContext)
+	if err != nil {
+		return 0
+	}
+	align := 0
+	for _, d := range devices {
+		if a := d.MemBaseAddrAlign() / 8; align == 0 || a < align {
+			align = a
+		}
+	}
+	return align
+}
+
+func (b *MemObject) getSubBufferInfo(info *MemObjectInfo) error {
+	var clMem C.cl_mem
+	if err := C.clGetMemObjectInfo(b.clMem, C.CL_MEM_ASSOCIATED_MEMOBJECT, C.size_t(unsafe.Sizeof(clMem)), unsafe.Pointer(&clMem), nil); err != C.CL_SUCCESS {
+		return toError(err)
+	}
+	if clMem == nil {
+		return nil
+	}
+	offset, err := b.getInfoSize(C.CL_MEM_OFFSET)
+	if err != nil {
+		return err
+	}
+	info.Offset = offset
+	if b.parent != nil && b.parent.clMem == clMem {
+		info.AssociatedMemObject = b.parent
+		return nil
+	}
+	if err := C.clRetainMemObject(clMem); err != C.CL_SUCCESS {
+		return toError(err)
+	}
+	size, err := (&MemObject{clMem: clMem}).getInfoSize(C.CL_MEM_SIZE)
+	if err != nil {
+		C.clReleaseMemObject(clMem)
+		return err
+	}
+	info.AssociatedMemObject = newMemObject(clMem, size)
+	return nil
+}
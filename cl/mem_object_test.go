@@ -0,0 +1,101 @@
+// +build !cl10
+
+package cl
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestErrMisalignedSubBufferIsErrMisalignedSubBufferOffset(t *testing.T) {
+	var err error = ErrMisalignedSubBuffer{Origin: 3, Alignment: 128}
+	if !errors.Is(err, ErrMisalignedSubBufferOffset) {
+		t.Fatalf("ErrMisalignedSubBuffer did not match ErrMisalignedSubBufferOffset")
+	}
+}
+
+func TestCreateSubBufferWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	align := devices[0].MemBaseAddrAlign() / 8
+	parent, err := context.CreateEmptyBuffer(MemReadWrite, align*4)
+	if err != nil {
+		t.Fatalf("CreateEmptyBuffer error %v", err)
+	}
+	sub, err := parent.CreateSubBuffer(MemReadWrite, align, align*2)
+	if err != nil {
+		t.Fatalf("CreateSubBuffer error %v", err)
+	}
+	if sub.parent != parent {
+		t.Fatalf("CreateSubBuffer did not keep a reference to its parent")
+	}
+	info, err := sub.Info()
+	if err != nil {
+		t.Fatalf("Info error %v", err)
+	}
+	if info.Type != MemObjectTypeBuffer || info.Size != align*2 || info.Offset != align {
+		t.Fatalf("Info expected buffer of size %d at offset %d got %+v", align*2, align, info)
+	}
+	if info.AssociatedMemObject != parent {
+		t.Fatalf("Info AssociatedMemObject was not the parent buffer")
+	}
+	if _, err := parent.CreateSubBuffer(MemReadWrite, 1, align); err == nil {
+		t.Fatalf("CreateSubBuffer with misaligned origin did not fail")
+	} else if _, ok := err.(ErrMisalignedSubBuffer); !ok {
+		t.Fatalf("CreateSubBuffer with misaligned origin returned %T %v", err, err)
+	}
+}
+
+func TestMemObjectOnDestroyWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	data := make([]byte, 1024)
+	buffer, err := context.CreateBuffer(MemReadWrite|MemUseHostPtr, data)
+	if err != nil {
+		t.Fatalf("CreateBuffer error %v", err)
+	}
+	destroyed := make(chan struct{})
+	if err := buffer.OnDestroy(func() { close(destroyed) }); err != nil {
+		t.Fatalf("OnDestroy error %v", err)
+	}
+	buffer.Release()
+	select {
+	case <-destroyed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("OnDestroy callback was not called after Release")
+	}
+}
+
+func TestHostPtrRefPinsUsedMemory(t *testing.T) {
+	data := make([]byte, 64)
+	if ref := newHostPtrRef(MemReadWrite|MemCopyHostPtr, unsafe.Pointer(&data[0])); ref != nil {
+		t.Fatalf("newHostPtrRef without MemUseHostPtr expected nil got %v", ref)
+	}
+	ref := newHostPtrRef(MemReadWrite|MemUseHostPtr, unsafe.Pointer(&data[0]))
+	if ref == nil {
+		t.Fatalf("newHostPtrRef with MemUseHostPtr returned nil")
+	}
+	ref.release()
+	hostMem, err := AllocHostMem(Uint8, 64, 0)
+	if err != nil {
+		t.Fatalf("AllocHostMem error %v", err)
+	}
+	ref = newHostPtrRef(MemUseHostPtr, hostMem.Ptr())
+	if ref.hostMem != hostMem {
+		t.Fatalf("newHostPtrRef did not hold the AlignedHostMem")
+	}
+	hostMem.Free()
+	if hostMem.pending == nil {
+		t.Fatalf("AlignedHostMem was freed while referenced")
+	}
+	ref.release()
+	if hostMem.pending != nil {
+		t.Fatalf("release did not free the AlignedHostMem")
+	}
+}
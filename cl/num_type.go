@@ -27,6 +27,7 @@ const (
 	Uint64
 	Float64
 	Uint
+	Float16
 )
 
 const (
@@ -38,6 +39,9 @@ const (
 )
 
 func (t PrimitiveType) String() string {
+	if w := t.Width(); w > 1 {
+		return fmt.Sprintf("%sx%d", t.Scalar(), w)
+	}
 	switch t {
 	case Int8:
 		return "Int8"
@@ -61,6 +65,8 @@ func (t PrimitiveType) String() string {
 		return "Float64"
 	case Uint:
 		return "Uint"
+	case Float16:
+		return "Float16"
 	default:
 		panic(fmt.Sprintf("Unhandled PrimitiveType during String call"))
 	}
@@ -68,6 +74,9 @@ func (t PrimitiveType) String() string {
 
 // ClSrc is the OpenCL src code type string for a given PrimitiveType
 func (t PrimitiveType) ClSrc() string {
+	if w := t.Width(); w > 1 {
+		return fmt.Sprintf("%s%d", t.Scalar().ClSrc(), w)
+	}
 	switch t {
 	case Int8:
 		return "char"
@@ -91,13 +100,23 @@ func (t PrimitiveType) ClSrc() string {
 		return "double"
 	case Uint:
 		return "size_t"
+	case Float16:
+		return "half"
 	default:
 		panic(fmt.Sprintf("Unhandled PrimitiveType during ClSrc call"))
 	}
 }
 
-// SizeofT ..
+// SizeofT is the size of the type in OpenCL, where 3 element vectors take
+// the space of 4 element vectors.
 func (t PrimitiveType) SizeofT() uintptr {
+	switch w := t.Width(); w {
+	case 1:
+	case 3:
+		return 4 * t.Scalar().SizeofT()
+	default:
+		return uintptr(w) * t.Scalar().SizeofT()
+	}
 	switch t {
 	case Int8:
 		return size8
@@ -121,6 +140,8 @@ func (t PrimitiveType) SizeofT() uintptr {
 		return size64
 	case Uint:
 		return sizeT
+	case Float16:
+		return size16
 	default:
 		panic(fmt.Sprintf("Unhandled PrimitiveType during NumType.SizeofT(): %v", t))
 	}
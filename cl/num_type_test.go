@@ -1,6 +1,7 @@
 package cl
 
 import (
+	"errors"
 	"testing"
 	"unsafe"
 )
@@ -71,3 +72,79 @@ func TestSizeofNumTypedForPrimitiveType(t *testing.T) {
 		t.Fatalf("Uint.SizeofT() error expected %d got %d", expected, size)
 	}
 }
+
+func TestVectorPrimitiveTypes(t *testing.T) {
+	cases := []struct {
+		t       PrimitiveType
+		sizeofT uintptr
+		clSrc   string
+		str     string
+	}{
+		{Float32x4, 16, "float4", "Float32x4"},
+		{Float32x3, 16, "float3", "Float32x3"},
+		{Int8x3, 4, "char3", "Int8x3"},
+		{Uint16x2, 4, "ushort2", "Uint16x2"},
+		{Float64x16, 128, "double16", "Float64x16"},
+		{Uint64x8, 64, "ulong8", "Uint64x8"},
+	}
+	for _, c := range cases {
+		if size := c.t.SizeofT(); size != c.sizeofT {
+			t.Fatalf("%v.SizeofT() expected %d got %d", c.t, c.sizeofT, size)
+		}
+		if src := c.t.ClSrc(); src != c.clSrc {
+			t.Fatalf("%v.ClSrc() expected %q got %q", c.t, c.clSrc, src)
+		}
+		if str := c.t.String(); str != c.str {
+			t.Fatalf("String() expected %q got %q", c.str, str)
+		}
+		parsed, err := ParsePrimitiveType(c.clSrc)
+		if err != nil || parsed != c.t {
+			t.Fatalf("ParsePrimitiveType(%q) expected %v got %v %v", c.clSrc, c.t, parsed, err)
+		}
+	}
+	if vt, err := VectorType(Float32, 4); err != nil || vt != Float32x4 || vt.Scalar() != Float32 || vt.Width() != 4 {
+		t.Fatalf("VectorType(Float32, 4) expected Float32x4 got %v %v", vt, err)
+	}
+	if _, err := VectorType(Uint, 2); err != ErrInvalidNumType {
+		t.Fatalf("VectorType(Uint, 2) expected ErrInvalidNumType got %v", err)
+	}
+}
+
+func TestParsePrimitiveType(t *testing.T) {
+	for name, expected := range map[string]PrimitiveType{
+		"int":            Int32,
+		"unsigned  char": Uint8,
+		" size_t ":       Uint,
+		"double":         Float64,
+		"uchar16":        Uint8x16,
+	} {
+		if parsed, err := ParsePrimitiveType(name); err != nil || parsed != expected {
+			t.Fatalf("ParsePrimitiveType(%q) expected %v got %v %v", name, expected, parsed, err)
+		}
+	}
+	for _, name := range []string{"float5", "float1", "float04", "size_t2", "unsigned int4", "bool", "float*"} {
+		if _, err := ParsePrimitiveType(name); !errors.Is(err, ErrInvalidNumType) {
+			t.Fatalf("ParsePrimitiveType(%q) expected ErrInvalidNumType got %v", name, err)
+		}
+	}
+}
+
+func TestVectorArgBytesPadsThreeElementVectors(t *testing.T) {
+	data, ok := vectorArgBytes([3]float32{1, 2, 3})
+	if !ok || len(data) != 16 {
+		t.Fatalf("vectorArgBytes([3]float32) expected 16 bytes got %d %v", len(data), ok)
+	}
+	if _, ok := vectorArgBytes([5]float32{}); ok {
+		t.Fatalf("vectorArgBytes([5]float32) was accepted")
+	}
+	hostMem, err := NewHostMem([][4]float32{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	if err != nil || hostMem.Len() != 2 || hostMem.NumType() != Float32x4 || hostMem.SizeofT() != 16 {
+		t.Fatalf("NewHostMem([][4]float32) expected 2 Float32x4 got %v %v", hostMem, err)
+	}
+	if _, err := NewHostMem([][3]float32{{1, 2, 3}}); err != ErrInvalidNumType {
+		t.Fatalf("NewHostMem([][3]float32) expected ErrInvalidNumType got %v", err)
+	}
+	if _, err := NewHostMem([][4]float32{}); err != ErrHostMemWasEmpty {
+		t.Fatalf("NewHostMem of empty [][4]float32 expected ErrHostMemWasEmpty got %v", err)
+	}
+}
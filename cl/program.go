@@ -9,22 +9,91 @@ package cl
 #else
 #include <CL/cl.h>
 #endif
+#include <stdint.h>
 #include <stdlib.h>
+
+extern void goProgramNotify(cl_program program, uintptr_t handle);
+
+static void CL_CALLBACK programNotify(cl_program program, void *user_data) {
+	goProgramNotify(program, (uintptr_t)user_data);
+}
+
+static cl_int buildProgram(cl_program program, cl_uint num_devices, const cl_device_id *device_list, const char *options, uintptr_t handle) {
+	return clBuildProgram(program, num_devices, device_list, options, programNotify, (void *)handle);
+}
 */
 import "C"
 
 import (
 	"fmt"
 	"runtime"
+	"strings"
+	"sync"
 	"unsafe"
 )
 
-// ProgramBuildError ..
-type ProgramBuildError string
+// ProgramBuildError is returned when building, compiling or linking a
+// Program fails. Statuses has the build status and log of every device the
+// program was built for, including the ones it built successfully for.
+type ProgramBuildError struct {
+	Err      error
+	Options  string
+	Statuses []DeviceBuildStatus
+}
 
-// Error ..
 func (e ProgramBuildError) Error() string {
-	return fmt.Sprintf("cl: build error (%s)", string(e))
+	var failed []string
+	for i, status := range e.Statuses {
+		if status.Status != BuildStatusError {
+			continue
+		}
+		msg := fmt.Sprintf("device %d: %s", i, status.Status)
+		for _, d := range status.Diagnostics() {
+			if d.Severity == SeverityError {
+				msg += ": " + d.String()
+				break
+			}
+		}
+		failed = append(failed, msg)
+	}
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(failed, ", "))
+}
+
+// Unwrap returns the error reported by OpenCL, such as ErrBuildProgramFailure.
+func (e ProgramBuildError) Unwrap() error {
+	return e.Err
+}
+
+// Diagnostics returns the parsed diagnostics of all devices' build logs.
+func (e ProgramBuildError) Diagnostics() []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, status := range e.Statuses {
+		diagnostics = append(diagnostics, status.Diagnostics()...)
+	}
+	return diagnostics
+}
+
+// ProgramBinaryError is returned by CreateProgramWithBinary when a binary
+// could not be loaded for one or more devices. Statuses has one entry per
+// device, nil for the devices whose binary loaded successfully.
+type ProgramBinaryError struct {
+	Err      error
+	Statuses []error
+}
+
+func (e ProgramBinaryError) Error() string {
+	var failed []string
+	for i, err := range e.Statuses {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("device %d: %s", i, err))
+		}
+	}
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(failed, ", "))
+}
+
+// Unwrap returns the overall error reported by clCreateProgramWithBinary.
+func (e ProgramBinaryError) Unwrap() error {
+	return e.Err
 }
 
 // Program is the cl_program wrapping struct
@@ -45,7 +114,29 @@ func (p *Program) Release() {
 	releaseProgram(p)
 }
 
-// BuildProgram compiles the source code of the program on the given devices.
+// DeviceBuildStatus is the outcome of building a Program for one device.
+type DeviceBuildStatus struct {
+	Device *Device
+	Status BuildStatus
+	Log    string
+}
+
+// Diagnostics returns the diagnostics parsed from the build log, see ParseBuildLog.
+func (s DeviceBuildStatus) Diagnostics() []Diagnostic {
+	return ParseBuildLog(s.Log)
+}
+
+// BuildResult is delivered by BuildProgramAsync once the build has finished.
+// Err is non-nil if the build could not be started or failed on any device.
+type BuildResult struct {
+	Program  *Program
+	Statuses []DeviceBuildStatus
+	Err      error
+}
+
+// BuildProgram compiles the source code of the program on the given devices
+// (all devices of the program if nil). If the build fails on any device, the
+// error is a ProgramBuildError with the build logs.
 func (p *Program) BuildProgram(devices []*Device, options string) error {
 	var cOptions *C.char
 	if options != "" {
@@ -62,33 +153,255 @@ func (p *Program) BuildProgram(devices []*Device, options string) error {
 	}
 	statusCode := C.clBuildProgram(p.clProgram, numDevices, deviceListPtr, cOptions, nil, nil)
 	err := toError(statusCode)
-	if err != nil {
+	if err == ErrBuildProgramFailure {
+		return p.buildError(devices, options, err)
+	}
+	return err
+}
+
+// buildError returns a ProgramBuildError for the failure err of building for
+// devices (all devices of the program if nil), or err itself if the build
+// statuses cannot be queried.
+func (p *Program) buildError(devices []*Device, options string, err error) error {
+	if len(devices) == 0 {
+		devices = p.devices
+	}
+	statuses, statusErr := p.buildStatuses(devices, options)
+	if _, ok := statusErr.(ProgramBuildError); !ok {
 		return err
 	}
-	return nil
+	return ProgramBuildError{Err: err, Options: options, Statuses: statuses}
 }
 
-// BuildLogs ..
-func (p Program) BuildLogs() ([]string, error) {
-	logs := make([]string, len(p.devices))
-	var bLen C.size_t
-	var err error
-	for _, device := range p.devices {
-		// Get the byteSize
-		err = toError(C.clGetProgramBuildInfo(p.clProgram, device.id, C.CL_PROGRAM_BUILD_LOG, 0, nil, &bLen))
+// BuildProgramAsync starts compiling the source code of the program on the
+// given devices (all devices of the program if nil) and returns immediately.
+// The returned channel receives exactly one BuildResult, with the per-device
+// build status, once the OpenCL implementation reports the build as finished.
+func (p *Program) BuildProgramAsync(devices []*Device, options string) <-chan BuildResult {
+	if len(devices) == 0 {
+		devices = p.devices
+	}
+	results := make(chan BuildResult, 1)
+	var once sync.Once
+	finish := func(err error) {
+		once.Do(func() {
+			result := BuildResult{Program: p, Err: err}
+			switch err {
+			case nil:
+				result.Statuses, result.Err = p.buildStatuses(devices, options)
+			case ErrBuildProgramFailure:
+				result.Err = p.buildError(devices, options, err)
+				if buildErr, ok := result.Err.(ProgramBuildError); ok {
+					result.Statuses = buildErr.Statuses
+				}
+			}
+			results <- result
+		})
+	}
+	var cOptions *C.char
+	if options != "" {
+		cOptions = C.CString(options)
+		defer C.free(unsafe.Pointer(cOptions))
+	}
+	var deviceListPtr *C.cl_device_id
+	deviceList := buildDeviceIDList(devices)
+	if len(deviceList) > 0 {
+		deviceListPtr = &deviceList[0]
+	}
+	// The status queries are made off the implementation's callback thread.
+	handle := registerCallback(func() { go finish(nil) })
+	if err := toError(C.buildProgram(p.clProgram, C.cl_uint(len(deviceList)), deviceListPtr, cOptions, C.uintptr_t(handle))); err != nil {
+		unregisterCallback(handle)
+		finish(err)
+	}
+	return results
+}
+
+// buildStatuses returns the build status of each device, and a
+// ProgramBuildError if the build failed for any of them.
+func (p *Program) buildStatuses(devices []*Device, options string) ([]DeviceBuildStatus, error) {
+	statuses := make([]DeviceBuildStatus, len(devices))
+	failed := false
+	for i, device := range devices {
+		status, err := p.buildStatus(device)
+		if err != nil {
+			return nil, err
+		}
+		log, err := p.buildLog(device)
 		if err != nil {
 			return nil, err
 		}
-		buffer := make([]byte, bLen)
-		err = toError(C.clGetProgramBuildInfo(p.clProgram, device.id, C.CL_PROGRAM_BUILD_LOG, bLen, unsafe.Pointer(&buffer[0]), nil))
+		statuses[i] = DeviceBuildStatus{Device: device, Status: status, Log: log}
+		if status == BuildStatusError {
+			failed = true
+		}
+	}
+	if failed {
+		return statuses, ProgramBuildError{Err: ErrBuildProgramFailure, Options: options, Statuses: statuses}
+	}
+	return statuses, nil
+}
+
+func (p *Program) buildStatus(device *Device) (BuildStatus, error) {
+	var status C.cl_build_status
+	if err := C.clGetProgramBuildInfo(p.clProgram, device.id, C.CL_PROGRAM_BUILD_STATUS, C.size_t(unsafe.Sizeof(status)), unsafe.Pointer(&status), nil); err != C.CL_SUCCESS {
+		return BuildStatusNone, toError(err)
+	}
+	return BuildStatus(status), nil
+}
+
+func (p *Program) buildLog(device *Device) (string, error) {
+	return p.getBuildInfoString(device, C.CL_PROGRAM_BUILD_LOG)
+}
+
+// BuildLogs returns the build log of each device of the program, in order.
+func (p Program) BuildLogs() ([]string, error) {
+	logs := make([]string, len(p.devices))
+	for i, device := range p.devices {
+		log, err := p.buildLog(device)
 		if err != nil {
 			return nil, err
 		}
-		logs = append(logs, string(buffer))
+		logs[i] = log
 	}
 	return logs, nil
 }
 
+func (p *Program) clDevices() ([]*Device, error) {
+	var numDevices C.cl_uint
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_NUM_DEVICES, C.size_t(unsafe.Sizeof(numDevices)), unsafe.Pointer(&numDevices), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if numDevices == 0 {
+		return nil, nil
+	}
+	deviceIDs := make([]C.cl_device_id, numDevices)
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_DEVICES, C.size_t(unsafe.Sizeof(deviceIDs[0]))*C.size_t(numDevices), unsafe.Pointer(&deviceIDs[0]), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	devices := make([]*Device, numDevices)
+	for i, id := range deviceIDs {
+		devices[i] = &Device{id: id}
+	}
+	return devices, nil
+}
+
+// ProgramBuildInfo is the build information of a Program for one device.
+type ProgramBuildInfo struct {
+	Device  *Device
+	Status  BuildStatus
+	Options string
+	// BinaryType is only reported by OpenCL 1.2 and later.
+	BinaryType ProgramBinaryType
+	// GlobalVariableTotalSize is the size of the program scope global
+	// variables, only reported by OpenCL 2.0 and later devices.
+	GlobalVariableTotalSize int
+}
+
+// ProgramInfo is the information reported by clGetProgramInfo and
+// clGetProgramBuildInfo. NumKernels and KernelNames are only reported by
+// OpenCL 1.2 and later, once the program has been built.
+type ProgramInfo struct {
+	ReferenceCount int
+	Context        *Context
+	Devices        []*Device
+	Source         string
+	NumKernels     int
+	KernelNames    []string
+	Builds         []ProgramBuildInfo
+}
+
+func (p *Program) getInfoString(param C.cl_program_info) (string, error) {
+	return queryString(func(size C.size_t, value unsafe.Pointer, sizeRet *C.size_t) C.cl_int {
+		return C.clGetProgramInfo(p.clProgram, param, size, value, sizeRet)
+	})
+}
+
+func (p *Program) getBuildInfoString(device *Device, param C.cl_program_build_info) (string, error) {
+	return queryString(func(size C.size_t, value unsafe.Pointer, sizeRet *C.size_t) C.cl_int {
+		return C.clGetProgramBuildInfo(p.clProgram, device.id, param, size, value, sizeRet)
+	})
+}
+
+// Info returns what the OpenCL implementation reports about the program and
+// its build for each of its devices.
+func (p *Program) Info() (ProgramInfo, error) {
+	var info ProgramInfo
+	var refCount C.cl_uint
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_REFERENCE_COUNT, C.size_t(unsafe.Sizeof(refCount)), unsafe.Pointer(&refCount), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	var clContext C.cl_context
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_CONTEXT, C.size_t(unsafe.Sizeof(clContext)), unsafe.Pointer(&clContext), nil); err != C.CL_SUCCESS {
+		return info, toError(err)
+	}
+	context, err := newContextFromID(clContext)
+	if err != nil {
+		return info, err
+	}
+	devices, err := p.clDevices()
+	if err != nil {
+		return info, err
+	}
+	source, err := p.getInfoString(C.CL_PROGRAM_SOURCE)
+	if err != nil {
+		return info, err
+	}
+	info.ReferenceCount = int(refCount)
+	info.Context = context
+	info.Devices = devices
+	info.Source = source
+	info.Builds = make([]ProgramBuildInfo, len(devices))
+	for i, device := range devices {
+		build := &info.Builds[i]
+		build.Device = device
+		if build.Status, err = p.buildStatus(device); err != nil {
+			return info, err
+		}
+		if build.Options, err = p.getBuildInfoString(device, C.CL_PROGRAM_BUILD_OPTIONS); err != nil {
+			return info, err
+		}
+		if err := p.getBuildInfo12(device, build); err != nil {
+			return info, err
+		}
+	}
+	return info, p.getInfo12(&info)
+}
+
+// Binaries returns the devices the program is associated with and the
+// program binary for each of them, in the same order. A binary is empty for
+// a device the program has not been built for. The result can be passed to
+// Context.CreateProgramWithBinary to skip compiling the source again.
+func (p *Program) Binaries() ([]*Device, [][]byte, error) {
+	devices, err := p.clDevices()
+	if err != nil || len(devices) == 0 {
+		return nil, nil, err
+	}
+	sizes := make([]C.size_t, len(devices))
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_BINARY_SIZES, C.size_t(unsafe.Sizeof(sizes[0]))*C.size_t(len(sizes)), unsafe.Pointer(&sizes[0]), nil); err != C.CL_SUCCESS {
+		return nil, nil, toError(err)
+	}
+	// The implementation writes each binary into memory we provide, which must
+	// not be Go memory since the pointer list is itself passed to C.
+	cBinaries := make([]*C.uchar, len(devices))
+	for i, size := range sizes {
+		if size > 0 {
+			cBinaries[i] = (*C.uchar)(C.malloc(size))
+			defer C.free(unsafe.Pointer(cBinaries[i]))
+		}
+	}
+	if err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_BINARIES, C.size_t(unsafe.Sizeof(cBinaries[0]))*C.size_t(len(cBinaries)), unsafe.Pointer(&cBinaries[0]), nil); err != C.CL_SUCCESS {
+		return nil, nil, toError(err)
+	}
+	binaries := make([][]byte, len(devices))
+	for i, size := range sizes {
+		if size > 0 {
+			binaries[i] = C.GoBytes(unsafe.Pointer(cBinaries[i]), C.int(size))
+		}
+	}
+	return devices, binaries, nil
+}
+
 // CreateKernel returns the *Kernel of the given name.
 func (p *Program) CreateKernel(name string) (*Kernel, error) {
 	cName := C.CString(name)
@@ -102,3 +415,38 @@ func (p *Program) CreateKernel(name string) (*Kernel, error) {
 	runtime.SetFinalizer(kernel, releaseKernel)
 	return kernel, nil
 }
+
+// CreateKernels creates a kernel for every kernel function in the built
+// program, keyed by function name.
+func (p *Program) CreateKernels() (map[string]*Kernel, error) {
+	var numKernels C.cl_uint
+	if err := C.clCreateKernelsInProgram(p.clProgram, 0, nil, &numKernels); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	kernels := make(map[string]*Kernel, numKernels)
+	if numKernels == 0 {
+		return kernels, nil
+	}
+	clKernels := make([]C.cl_kernel, numKernels)
+	if err := C.clCreateKernelsInProgram(p.clProgram, numKernels, &clKernels[0], nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	for i, clKernel := range clKernels {
+		kernel := &Kernel{clKernel: clKernel}
+		runtime.SetFinalizer(kernel, releaseKernel)
+		name, err := kernel.getInfoString(C.CL_KERNEL_FUNCTION_NAME)
+		if err != nil {
+			kernel.Release()
+			for _, k := range kernels {
+				k.Release()
+			}
+			for _, clKernel := range clKernels[i+1:] {
+				C.clReleaseKernel(clKernel)
+			}
+			return nil, err
+		}
+		kernel.name = name
+		kernels[name] = kernel
+	}
+	return kernels, nil
+}
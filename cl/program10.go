@@ -0,0 +1,28 @@
+// +build cl10
+
+package cl
+
+// Compile is not supported by OpenCL 1.0
+func (p *Program) Compile(devices []*Device, options string, headers map[string]*Program) error {
+	return ErrUnsupported
+}
+
+// LinkPrograms is not supported by OpenCL 1.0
+func (ctx *Context) LinkPrograms(devices []*Device, options string, programs []*Program) (*Program, error) {
+	return nil, ErrUnsupported
+}
+
+// CreateProgramWithBuiltInKernels is not supported by OpenCL 1.0
+func (ctx *Context) CreateProgramWithBuiltInKernels(devices []*Device, names []string) (*Program, error) {
+	return nil, ErrUnsupported
+}
+
+// OpenCL 1.0 does not report kernel names.
+func (p *Program) getInfo12(info *ProgramInfo) error {
+	return nil
+}
+
+// OpenCL 1.0 does not report binary types.
+func (p *Program) getBuildInfo12(device *Device, build *ProgramBuildInfo) error {
+	return nil
+}
@@ -0,0 +1,219 @@
+// +build !cl10
+
+package cl
+
+/*
+#cgo CFLAGS: -I CL -w
+#cgo !darwin LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdlib.h>
+
+#ifndef CL_PROGRAM_BUILD_GLOBAL_VARIABLE_TOTAL_SIZE
+#define CL_PROGRAM_BUILD_GLOBAL_VARIABLE_TOTAL_SIZE 0x1185
+#endif
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// ErrBuiltInKernelNotAvailable is returned by CreateProgramWithBuiltInKernels
+// when a device does not advertise one of the requested built-in kernels.
+type ErrBuiltInKernelNotAvailable struct {
+	Name   string
+	Device *Device
+}
+
+func (e ErrBuiltInKernelNotAvailable) Error() string {
+	return fmt.Sprintf("cl: built-in kernel %q is not available on device %s", e.Name, e.Device.Name())
+}
+
+// Compile compiles the source code of the program on the given devices (all
+// devices of the program if nil) without linking it. headers maps the names
+// used in #include directives of the source to programs created with
+// CreateProgramWithSource that hold the header sources, so no header files
+// need to exist on disk. If compilation fails the error is a ProgramBuildError.
+func (p *Program) Compile(devices []*Device, options string, headers map[string]*Program) error {
+	for _, header := range headers {
+		if header == nil {
+			return ErrInvalidValue
+		}
+	}
+	var cOptions *C.char
+	if options != "" {
+		cOptions = C.CString(options)
+		defer C.free(unsafe.Pointer(cOptions))
+	}
+	var deviceListPtr *C.cl_device_id
+	deviceList := buildDeviceIDList(devices)
+	if len(deviceList) > 0 {
+		deviceListPtr = &deviceList[0]
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var headerListPtr *C.cl_program
+	var headerNamesPtr **C.char
+	headerList := make([]C.cl_program, len(names))
+	headerNames := make([]*C.char, len(names))
+	for i, name := range names {
+		headerList[i] = headers[name].clProgram
+		headerNames[i] = C.CString(name)
+		defer C.free(unsafe.Pointer(headerNames[i]))
+	}
+	if len(names) > 0 {
+		headerListPtr = &headerList[0]
+		headerNamesPtr = &headerNames[0]
+	}
+	err := toError(C.clCompileProgram(p.clProgram, C.cl_uint(len(deviceList)), deviceListPtr, cOptions, C.cl_uint(len(names)), headerListPtr, headerNamesPtr, nil, nil))
+	if err == ErrCompileProgramFailure {
+		return p.buildError(devices, options, err)
+	}
+	return err
+}
+
+// LinkPrograms links the compiled programs into a new program for the given
+// devices (all devices of the context if nil). Pass "-create-library" in
+// options to create a library that can itself be linked into other programs.
+//
+// If linking fails after the program object was created, the program is
+// returned together with the error, a ProgramBuildError with the build logs.
+func (ctx *Context) LinkPrograms(devices []*Device, options string, programs []*Program) (*Program, error) {
+	for _, program := range programs {
+		if program == nil {
+			return nil, ErrInvalidValue
+		}
+	}
+	var cOptions *C.char
+	if options != "" {
+		cOptions = C.CString(options)
+		defer C.free(unsafe.Pointer(cOptions))
+	}
+	if len(devices) == 0 {
+		devices = ctx.devices
+	}
+	var deviceListPtr *C.cl_device_id
+	deviceList := buildDeviceIDList(devices)
+	if len(deviceList) > 0 {
+		deviceListPtr = &deviceList[0]
+	}
+	programList := make([]C.cl_program, len(programs))
+	for i, program := range programs {
+		programList[i] = program.clProgram
+	}
+	var programListPtr *C.cl_program
+	if len(programList) > 0 {
+		programListPtr = &programList[0]
+	}
+	var err C.cl_int
+	clProgram := C.clLinkProgram(ctx.clContext, C.cl_uint(len(deviceList)), deviceListPtr, cOptions, C.cl_uint(len(programList)), programListPtr, nil, nil, &err)
+	if clProgram == nil {
+		if err != C.CL_SUCCESS {
+			return nil, toError(err)
+		}
+		return nil, ErrUnknown
+	}
+	program := &Program{clProgram: clProgram, devices: devices}
+	runtime.SetFinalizer(program, releaseProgram)
+	linkErr := toError(err)
+	if linkErr == ErrLinkProgramFailure {
+		linkErr = program.buildError(devices, options, linkErr)
+	}
+	return program, linkErr
+}
+
+// CreateProgramWithBuiltInKernels creates a program for the given devices
+// (all devices of the context if nil) from the named built-in kernels. Every
+// name must be listed in the BuiltInKernels of every device, names that are
+// empty or contain a semicolon are rejected with ErrInvalidValue. Kernels are
+// then created from the program with CreateKernel as usual.
+func (ctx *Context) CreateProgramWithBuiltInKernels(devices []*Device, names []string) (*Program, error) {
+	if len(devices) == 0 {
+		devices = ctx.devices
+	}
+	if len(names) == 0 {
+		return nil, ErrInvalidValue
+	}
+	trimmed := make([]string, len(names))
+	for i, name := range names {
+		trimmed[i] = strings.TrimSpace(name)
+		if trimmed[i] == "" || strings.Contains(trimmed[i], ";") {
+			return nil, ErrInvalidValue
+		}
+	}
+	for _, device := range devices {
+		available := make(map[string]bool)
+		for _, name := range device.BuiltInKernels() {
+			// the list is a single empty string without built-in kernels
+			if name = strings.TrimSpace(name); name != "" {
+				available[name] = true
+			}
+		}
+		for _, name := range trimmed {
+			if !available[name] {
+				return nil, ErrBuiltInKernelNotAvailable{Name: name, Device: device}
+			}
+		}
+	}
+	deviceList := buildDeviceIDList(devices)
+	cNames := C.CString(strings.Join(trimmed, ";"))
+	defer C.free(unsafe.Pointer(cNames))
+	var err C.cl_int
+	clProgram := C.clCreateProgramWithBuiltInKernels(ctx.clContext, C.cl_uint(len(deviceList)), &deviceList[0], cNames, &err)
+	if err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if clProgram == nil {
+		return nil, ErrUnknown
+	}
+	program := &Program{clProgram: clProgram, devices: devices}
+	runtime.SetFinalizer(program, releaseProgram)
+	return program, nil
+}
+
+func (p *Program) getInfo12(info *ProgramInfo) error {
+	var numKernels C.size_t
+	err := C.clGetProgramInfo(p.clProgram, C.CL_PROGRAM_NUM_KERNELS, C.size_t(unsafe.Sizeof(numKernels)), unsafe.Pointer(&numKernels), nil)
+	if err == C.CL_INVALID_PROGRAM_EXECUTABLE {
+		// not built yet
+		return nil
+	}
+	if err != C.CL_SUCCESS {
+		return toError(err)
+	}
+	names, nameErr := p.getInfoString(C.CL_PROGRAM_KERNEL_NAMES)
+	if nameErr != nil {
+		return nameErr
+	}
+	info.NumKernels = int(numKernels)
+	if names != "" {
+		info.KernelNames = strings.Split(names, ";")
+	}
+	return nil
+}
+
+func (p *Program) getBuildInfo12(device *Device, build *ProgramBuildInfo) error {
+	var binaryType C.cl_program_binary_type
+	if err := C.clGetProgramBuildInfo(p.clProgram, device.id, C.CL_PROGRAM_BINARY_TYPE, C.size_t(unsafe.Sizeof(binaryType)), unsafe.Pointer(&binaryType), nil); err != C.CL_SUCCESS {
+		return toError(err)
+	}
+	build.BinaryType = ProgramBinaryType(binaryType)
+	// Devices before OpenCL 2.0 reject the query with CL_INVALID_VALUE.
+	var globalSize C.size_t
+	if err := C.clGetProgramBuildInfo(p.clProgram, device.id, C.CL_PROGRAM_BUILD_GLOBAL_VARIABLE_TOTAL_SIZE, C.size_t(unsafe.Sizeof(globalSize)), unsafe.Pointer(&globalSize), nil); err == C.CL_SUCCESS {
+		build.GlobalVariableTotalSize = int(globalSize)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// +build !cl10
+
+package cl
+
+import "testing"
+
+var squareHeaderSource = `
+float square(float x) { return x * x; }
+`
+
+var squareLibrarySource = `
+#include "square.h"
+float squareLib(float x) { return square(x); }
+`
+
+var squareUserSource = `
+float squareLib(float x);
+__kernel void squareAll(__global float* data, const unsigned int count)
+{
+   int i = get_global_id(0);
+   if(i < count)
+       data[i] = squareLib(data[i]);
+}
+`
+
+func TestCompileAndLinkProgramsWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	header, err := context.CreateProgramWithSource([]string{squareHeaderSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	lib, err := context.CreateProgramWithSource([]string{squareLibrarySource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := lib.Compile(nil, "", map[string]*Program{"square.h": header}); err != nil {
+		t.Fatalf("Compile error %v", err)
+	}
+	library, err := context.LinkPrograms(nil, "-create-library", []*Program{lib})
+	if err != nil {
+		t.Fatalf("LinkPrograms library error %v", err)
+	}
+	user, err := context.CreateProgramWithSource([]string{squareUserSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := user.Compile(nil, "", nil); err != nil {
+		t.Fatalf("Compile error %v", err)
+	}
+	program, err := context.LinkPrograms(nil, "", []*Program{user, library})
+	if err != nil {
+		t.Fatalf("LinkPrograms error %v", err)
+	}
+	if _, err := program.CreateKernel("squareAll"); err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+}
+
+func TestCompileAndLinkRejectNilPrograms(t *testing.T) {
+	if err := (&Program{}).Compile(nil, "", map[string]*Program{"square.h": nil}); err != ErrInvalidValue {
+		t.Fatalf("Compile with nil header expected ErrInvalidValue got %v", err)
+	}
+	if _, err := (&Context{}).LinkPrograms(nil, "", []*Program{nil}); err != ErrInvalidValue {
+		t.Fatalf("LinkPrograms of nil program expected ErrInvalidValue got %v", err)
+	}
+}
+
+func TestLinkProgramsFailureWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	user, err := context.CreateProgramWithSource([]string{squareUserSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := user.Compile(nil, "", nil); err != nil {
+		t.Fatalf("Compile error %v", err)
+	}
+	// squareLib is declared but never defined
+	_, err = context.LinkPrograms(nil, "", []*Program{user})
+	buildErr, ok := err.(ProgramBuildError)
+	if !ok {
+		t.Fatalf("LinkPrograms expected ProgramBuildError got %T %v", err, err)
+	}
+	if buildErr.Err != ErrLinkProgramFailure || len(buildErr.Statuses) != len(devices) {
+		t.Fatalf("ProgramBuildError expected ErrLinkProgramFailure and %d statuses got %+v", len(devices), buildErr)
+	}
+}
+
+func TestCreateProgramWithBuiltInKernelsValidatesNames(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	_, err = context.CreateProgramWithBuiltInKernels(nil, []string{"no_such_built_in_kernel"})
+	if e, ok := err.(ErrBuiltInKernelNotAvailable); !ok {
+		t.Fatalf("CreateProgramWithBuiltInKernels expected ErrBuiltInKernelNotAvailable got %v", err)
+	} else if e.Name != "no_such_built_in_kernel" || e.Device.id != devices[0].id {
+		t.Fatalf("CreateProgramWithBuiltInKernels error was for the wrong kernel or device: %v", e)
+	}
+	for _, name := range []string{"", " ", "a;b"} {
+		if _, err := context.CreateProgramWithBuiltInKernels(nil, []string{name}); err != ErrInvalidValue {
+			t.Fatalf("CreateProgramWithBuiltInKernels(%q) expected ErrInvalidValue got %v", name, err)
+		}
+	}
+}
+
+func TestProgramInfoWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, "-cl-fast-relaxed-math"); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	info, err := program.Info()
+	if err != nil {
+		t.Fatalf("Info error %v", err)
+	}
+	if info.Source != kernelSource || len(info.Devices) != len(devices) || info.Context == nil {
+		t.Fatalf("Info expected the source and %d devices got %+v", len(devices), info)
+	}
+	if info.NumKernels != 1 || len(info.KernelNames) != 1 || info.KernelNames[0] != "square" {
+		t.Fatalf("Info expected kernel square got %d %v", info.NumKernels, info.KernelNames)
+	}
+	for _, build := range info.Builds {
+		if build.Status != BuildStatusSuccess || build.Options != "-cl-fast-relaxed-math" || build.BinaryType != ProgramBinaryTypeExecutable {
+			t.Fatalf("Info expected a successful executable build with the options got %+v", build)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package cl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrProgramCacheEntryCorrupt is returned when a cache entry fails its checksum.
+var ErrProgramCacheEntryCorrupt = errors.New("cl: program cache entry is corrupt")
+
+const (
+	programCacheEntryExt    = ".clbin"
+	programCacheTempPattern = "tmp-*" + programCacheEntryExt
+	programCacheTempMaxAge  = time.Hour
+)
+
+// ProgramCache stores compiled program binaries on disk so that identical
+// programs don't have to be compiled from source again. Entries are keyed by
+// a hash of the sources, the build options, the device name, the driver
+// version and the platform version, so a driver upgrade invalidates them.
+//
+// Entries are written to a temporary file and renamed into place, so several
+// processes can safely share the same cache directory. Entries are readable by
+// all users, and temporary files left behind by crashed writers are removed
+// after an hour.
+type ProgramCache struct {
+	dir string
+
+	// OnStoreError, if set, is called when BuildProgram built a program
+	// but could not store its binaries, e.g. because the cache directory is
+	// read-only or full. The built program is returned regardless.
+	OnStoreError func(err error)
+}
+
+// NewProgramCache returns a ProgramCache that stores entries in dir, creating
+// the directory if needed.
+func NewProgramCache(dir string) (*ProgramCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &ProgramCache{dir: dir}, nil
+}
+
+// Dir is the directory the cache stores its entries in.
+func (c *ProgramCache) Dir() string {
+	return c.dir
+}
+
+// BuildProgram returns a built program for the sources on the given devices
+// (all devices of the context if nil). If every device has a cache entry the
+// program is created from the cached binaries, otherwise it is built from
+// source and the resulting binaries are stored. Corrupt or stale entries are
+// removed and the program is rebuilt from source. Failing to store the
+// binaries does not fail the build, see OnStoreError.
+func (c *ProgramCache) BuildProgram(ctx *Context, devices []*Device, sources []string, options string) (*Program, error) {
+	if len(devices) == 0 {
+		devices = ctx.devices
+	}
+	keys := make([]string, len(devices))
+	for i, device := range devices {
+		key, err := programCacheKey(device, sources, options)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	if program, err := c.load(ctx, devices, keys, options); err == nil {
+		return program, nil
+	}
+	program, err := ctx.CreateProgramWithSource(sources)
+	if err != nil {
+		return nil, err
+	}
+	if err := program.BuildProgram(devices, options); err != nil {
+		return nil, err
+	}
+	if err := c.store(program, devices, keys); err != nil && c.OnStoreError != nil {
+		c.OnStoreError(err)
+	}
+	return program, nil
+}
+
+func (c *ProgramCache) load(ctx *Context, devices []*Device, keys []string, options string) (*Program, error) {
+	binaries := make([][]byte, len(keys))
+	for i, key := range keys {
+		binary, err := readProgramCacheEntry(c.path(key))
+		if err == ErrProgramCacheEntryCorrupt {
+			os.Remove(c.path(key))
+		}
+		if err != nil {
+			return nil, err
+		}
+		binaries[i] = binary
+	}
+	program, err := ctx.CreateProgramWithBinary(devices, binaries)
+	if err == nil {
+		err = program.BuildProgram(devices, options)
+	}
+	if err != nil {
+		// The driver rejected the binaries, e.g. they are stale
+		c.remove(keys)
+		return nil, err
+	}
+	return program, nil
+}
+
+func (c *ProgramCache) store(program *Program, devices []*Device, keys []string) error {
+	c.removeStaleTempFiles()
+	programDevices, binaries, err := program.Binaries()
+	if err != nil {
+		return err
+	}
+	for i, device := range devices {
+		for j, programDevice := range programDevices {
+			if programDevice.id == device.id && len(binaries[j]) > 0 {
+				if err := writeProgramCacheEntry(c.dir, c.path(keys[i]), binaries[j]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// removeStaleTempFiles removes the temporary files of writes that never
+// finished, e.g. because the process crashed. Only old files are removed, so
+// writes in progress in other processes are left alone.
+func (c *ProgramCache) removeStaleTempFiles() {
+	matches, _ := filepath.Glob(filepath.Join(c.dir, programCacheTempPattern))
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > programCacheTempMaxAge {
+			os.Remove(path)
+		}
+	}
+}
+
+func (c *ProgramCache) remove(keys []string) {
+	for _, key := range keys {
+		os.Remove(c.path(key))
+	}
+}
+
+func (c *ProgramCache) path(key string) string {
+	return filepath.Join(c.dir, key+programCacheEntryExt)
+}
+
+func programCacheKey(device *Device, sources []string, options string) (string, error) {
+	platform, err := device.Platform()
+	if err != nil {
+		return "", err
+	}
+	return programCacheHash(append([]string{
+		device.Name(),
+		device.DriverVersion(),
+		platform.Version(),
+		options,
+	}, sources...)), nil
+}
+
+// programCacheHash hashes the length prefixed parts so that moving bytes
+// from one part to the next changes the hash.
+func programCacheHash(parts []string) string {
+	h := sha256.New()
+	var n [8]byte
+	for _, part := range parts {
+		binary.LittleEndian.PutUint64(n[:], uint64(len(part)))
+		h.Write(n[:])
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// A cache entry is the sha256 of the binary followed by the binary.
+func readProgramCacheEntry(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) <= sha256.Size {
+		return nil, ErrProgramCacheEntryCorrupt
+	}
+	sum := sha256.Sum256(data[sha256.Size:])
+	if !bytes.Equal(sum[:], data[:sha256.Size]) {
+		return nil, ErrProgramCacheEntryCorrupt
+	}
+	return data[sha256.Size:], nil
+}
+
+func writeProgramCacheEntry(dir, path string, binary []byte) error {
+	f, err := os.CreateTemp(dir, programCacheTempPattern)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(binary)
+	// CreateTemp makes the file private, but the cache may be shared
+	err = f.Chmod(0644)
+	if err == nil {
+		_, err = f.Write(sum[:])
+	}
+	if err == nil {
+		_, err = f.Write(binary)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package cl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProgramCacheHashSeparatesParts(t *testing.T) {
+	if programCacheHash([]string{"ab", "c"}) == programCacheHash([]string{"a", "bc"}) {
+		t.Fatalf("programCacheHash did not separate parts")
+	}
+	if programCacheHash([]string{"a", "b"}) != programCacheHash([]string{"a", "b"}) {
+		t.Fatalf("programCacheHash was not deterministic")
+	}
+}
+
+func TestProgramCacheEntryRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "entry"+programCacheEntryExt)
+	binary := []byte("not really a binary")
+	if err := writeProgramCacheEntry(dir, path, binary); err != nil {
+		t.Fatalf("writeProgramCacheEntry error %v", err)
+	}
+	got, err := readProgramCacheEntry(path)
+	if err != nil {
+		t.Fatalf("readProgramCacheEntry error %v", err)
+	}
+	if !bytes.Equal(got, binary) {
+		t.Fatalf("readProgramCacheEntry expected %q got %q", binary, got)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Fatalf("writeProgramCacheEntry expected mode 0644 got %v (%v)", info.Mode(), err)
+	}
+	data, _ := os.ReadFile(path)
+	data[len(data)-1] ^= 0xff
+	os.WriteFile(path, data, 0644)
+	if _, err := readProgramCacheEntry(path); err != ErrProgramCacheEntryCorrupt {
+		t.Fatalf("readProgramCacheEntry of corrupt entry expected ErrProgramCacheEntryCorrupt got %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "tmp-*"))
+	if len(matches) != 0 {
+		t.Fatalf("writeProgramCacheEntry left temporary files %v", matches)
+	}
+}
+
+func TestProgramCacheRemovesStaleTempFiles(t *testing.T) {
+	cache, err := NewProgramCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewProgramCache error %v", err)
+	}
+	stale := filepath.Join(cache.Dir(), "tmp-stale"+programCacheEntryExt)
+	fresh := filepath.Join(cache.Dir(), "tmp-fresh"+programCacheEntryExt)
+	os.WriteFile(stale, nil, 0600)
+	os.WriteFile(fresh, nil, 0600)
+	old := time.Now().Add(-2 * programCacheTempMaxAge)
+	os.Chtimes(stale, old, old)
+	cache.removeStaleTempFiles()
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("removeStaleTempFiles did not remove the stale file")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("removeStaleTempFiles removed a fresh file: %v", err)
+	}
+}
+
+func TestProgramCacheBuildProgramWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	cache, err := NewProgramCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewProgramCache error %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		program, err := cache.BuildProgram(context, nil, []string{kernelSource}, "")
+		if err != nil {
+			t.Fatalf("ProgramCache.BuildProgram %d error %v", i, err)
+		}
+		if _, err := program.CreateKernel("square"); err != nil {
+			t.Fatalf("CreateKernel %d error %v", i, err)
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(cache.Dir(), "*"+programCacheEntryExt))
+	if len(matches) != len(devices) {
+		t.Fatalf("ProgramCache expected %d entries got %d", len(devices), len(matches))
+	}
+}
+
+func TestProgramCacheBuildProgramStoreError(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	cache, err := NewProgramCache(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatalf("NewProgramCache error %v", err)
+	}
+	os.RemoveAll(cache.Dir())
+	var storeErr error
+	cache.OnStoreError = func(err error) { storeErr = err }
+	program, err := cache.BuildProgram(context, nil, []string{kernelSource}, "")
+	if err != nil {
+		t.Fatalf("ProgramCache.BuildProgram error %v", err)
+	}
+	if program == nil {
+		t.Fatalf("ProgramCache.BuildProgram returned no program")
+	}
+	if storeErr == nil {
+		t.Fatalf("ProgramCache.OnStoreError was not called")
+	}
+}
@@ -0,0 +1,174 @@
+package cl
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuildProgramAsyncWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	select {
+	case result := <-program.BuildProgramAsync(nil, ""):
+		if result.Err != nil {
+			t.Fatalf("BuildProgramAsync error %v", result.Err)
+		}
+		if len(result.Statuses) != len(devices) {
+			t.Fatalf("BuildProgramAsync expected %d statuses got %d", len(devices), len(result.Statuses))
+		}
+		for _, s := range result.Statuses {
+			if s.Status != BuildStatusSuccess {
+				t.Fatalf("BuildProgramAsync status for %s was %s", s.Device.Name(), s.Status)
+			}
+		}
+	case <-time.After(time.Minute):
+		t.Fatalf("BuildProgramAsync did not finish")
+	}
+	if _, err := program.CreateKernel("square"); err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+}
+
+func TestProgramBinaryErrorReportsFailedDevices(t *testing.T) {
+	err := ProgramBinaryError{
+		Err:      ErrInvalidBinary,
+		Statuses: []error{nil, ErrInvalidBinary},
+	}
+	expected := "cl: Invalid Binary (device 1: cl: Invalid Binary)"
+	if err.Error() != expected {
+		t.Fatalf("ProgramBinaryError.Error() expected %q got %q", expected, err.Error())
+	}
+	if !errors.Is(err, ErrInvalidBinary) {
+		t.Fatalf("ProgramBinaryError did not unwrap to ErrInvalidBinary")
+	}
+}
+
+func TestCreateProgramWithBinaryWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{kernelSource})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, ""); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	devices, binaries, err := program.Binaries()
+	if err != nil {
+		t.Fatalf("Binaries error %v", err)
+	}
+	loaded, err := context.CreateProgramWithBinary(devices, binaries)
+	if err != nil {
+		t.Fatalf("CreateProgramWithBinary error %v", err)
+	}
+	if err := loaded.BuildProgram(devices, ""); err != nil {
+		t.Fatalf("BuildProgram from binary error %v", err)
+	}
+	if _, err := loaded.CreateKernel("square"); err != nil {
+		t.Fatalf("CreateKernel error %v", err)
+	}
+}
+
+func TestProgramBuildErrorString(t *testing.T) {
+	err := ProgramBuildError{
+		Err:     ErrBuildProgramFailure,
+		Options: "-Werror",
+		Statuses: []DeviceBuildStatus{
+			{Status: BuildStatusSuccess},
+			{Status: BuildStatusError, Log: "<source>:2:3: warning: w\n<source>:4:1: error: e\n"},
+		},
+	}
+	expected := "cl: Build Program Failure (device 1: Error: <source>:4:1: error: e)"
+	if err.Error() != expected {
+		t.Fatalf("ProgramBuildError.Error() expected %q got %q", expected, err.Error())
+	}
+	if !errors.Is(err, ErrBuildProgramFailure) {
+		t.Fatalf("ProgramBuildError did not match ErrBuildProgramFailure")
+	}
+	if diagnostics := err.Diagnostics(); len(diagnostics) != 2 || diagnostics[1].Line != 4 {
+		t.Fatalf("ProgramBuildError.Diagnostics() got %+v", diagnostics)
+	}
+}
+
+func TestBuildProgramFailureWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{"__kernel void broken() { undeclared = 1; }"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	err = program.BuildProgram(nil, "-w")
+	buildErr, ok := err.(ProgramBuildError)
+	if !ok {
+		t.Fatalf("BuildProgram expected ProgramBuildError got %T %v", err, err)
+	}
+	if buildErr.Options != "-w" || len(buildErr.Statuses) != len(devices) {
+		t.Fatalf("ProgramBuildError expected options -w and %d statuses got %+v", len(devices), buildErr)
+	}
+	logs, err := program.BuildLogs()
+	if err != nil {
+		t.Fatalf("BuildLogs error %v", err)
+	}
+	if len(logs) != len(devices) || logs[0] == "" {
+		t.Fatalf("BuildLogs expected %d non-empty logs got %q", len(devices), logs)
+	}
+}
+
+func TestBuildProgramAsyncFailureWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	program, err := context.CreateProgramWithSource([]string{"__kernel void broken() { undeclared = 1; }"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	select {
+	case result := <-program.BuildProgramAsync(nil, ""):
+		buildErr, ok := result.Err.(ProgramBuildError)
+		if !ok {
+			t.Fatalf("BuildProgramAsync expected ProgramBuildError got %T %v", result.Err, result.Err)
+		}
+		if len(buildErr.Statuses) != len(devices) || len(result.Statuses) != len(devices) {
+			t.Fatalf("BuildProgramAsync expected %d statuses got %+v", len(devices), result)
+		}
+	case <-time.After(time.Minute):
+		t.Fatalf("BuildProgramAsync did not finish")
+	}
+}
+
+func TestCreateKernelsWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	source := kernelSource + "\n__kernel void twice(__global float* data) { data[get_global_id(0)] *= 2; }\n"
+	program, err := context.CreateProgramWithSource([]string{source})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource error %v", err)
+	}
+	if err := program.BuildProgram(nil, ""); err != nil {
+		t.Fatalf("BuildProgram error %v", err)
+	}
+	kernels, err := program.CreateKernels()
+	if err != nil {
+		t.Fatalf("CreateKernels error %v", err)
+	}
+	if len(kernels) != 2 || kernels["square"] == nil || kernels["twice"] == nil {
+		t.Fatalf("CreateKernels expected square and twice got %v", kernels)
+	}
+	if name := kernels["twice"].Name(); name != "twice" {
+		t.Fatalf("CreateKernels kernel name expected twice got %q", name)
+	}
+}
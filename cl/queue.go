@@ -13,6 +13,8 @@ package cl
 import "C"
 
 import (
+	"context"
+	"runtime"
 	"unsafe"
 )
 
@@ -38,6 +40,21 @@ func releaseCommandQueue(q *CommandQueue) {
 	}
 }
 
+// newCommandQueueFromID wraps a cl_command_queue obtained from another object,
+// retaining it so that the returned CommandQueue can be released independently.
+func newCommandQueueFromID(clQueue C.cl_command_queue) (*CommandQueue, error) {
+	var deviceID C.cl_device_id
+	if err := C.clGetCommandQueueInfo(clQueue, C.CL_QUEUE_DEVICE, C.size_t(unsafe.Sizeof(deviceID)), unsafe.Pointer(&deviceID), nil); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if err := C.clRetainCommandQueue(clQueue); err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	commandQueue := &CommandQueue{clQueue: clQueue, device: &Device{id: deviceID}}
+	runtime.SetFinalizer(commandQueue, releaseCommandQueue)
+	return commandQueue, nil
+}
+
 // Release calls clReleaseCommandQueue on the CommandQueue. Using the CommandQueue after Release will cause a panic.
 func (q *CommandQueue) Release() {
 	releaseCommandQueue(q)
@@ -48,6 +65,26 @@ func (q *CommandQueue) Finish() error {
 	return toError(C.clFinish(q.clQueue))
 }
 
+// FinishContext is like Finish but returns ctx.Err() as soon as ctx is done.
+// The queued commands keep executing on the device unless they are gated on
+// a user event passed in cancelEvents: each of those is then set to a
+// negative status, which terminates all enqueued commands waiting on it.
+// Cancellation only stops the wait: the goroutine calling clFinish, and the
+// OS thread it runs on, stay blocked until the queue has drained.
+func (q *CommandQueue) FinishContext(ctx context.Context, cancelEvents ...*Event) error {
+	finished := make(chan error, 1)
+	go func() {
+		finished <- q.Finish()
+	}()
+	select {
+	case err := <-finished:
+		return err
+	case <-ctx.Done():
+		failUserEvents(cancelEvents)
+		return ctx.Err()
+	}
+}
+
 // Flush issues all previously queued OpenCL commands in a command-queue to the device associated with the command-queue.
 func (q *CommandQueue) Flush() error {
 	return toError(C.clFinish(q.clQueue))
@@ -112,9 +149,16 @@ func (q *CommandQueue) EnqueueWriteBuffer(buffer *MemObject, blocking bool, offs
 
 // EnqueueWriteBufferFloat32 ..
 func (q *CommandQueue) EnqueueWriteBufferFloat32(buffer *MemObject, blocking bool, offset int, data []float32, eventWaitList []*Event) (*Event, error) {
-	dataPtr := unsafe.Pointer(&data[0])
-	dataSize := int(unsafe.Sizeof(data[0])) * len(data)
-	return q.EnqueueWriteBuffer(buffer, blocking, offset, dataSize, dataPtr, eventWaitList)
+	return q.EnqueueWriteBufferHostMem(buffer, blocking, offset, SliceF32(data), eventWaitList)
+}
+
+// EnqueueWriteBufferHostMem enqueues a command to write all of the HostMem to
+// a buffer object starting at offset bytes.
+func (q *CommandQueue) EnqueueWriteBufferHostMem(buffer *MemObject, blocking bool, offset int, h HostMem, eventWaitList []*Event) (*Event, error) {
+	if err := checkHostMem(h); err != nil {
+		return nil, err
+	}
+	return q.EnqueueWriteBuffer(buffer, blocking, offset, hostMemSize(h), h.Ptr(), eventWaitList)
 }
 
 // EnqueueReadBuffer enqueues commands to read from a buffer object to host memory.
@@ -126,9 +170,16 @@ func (q *CommandQueue) EnqueueReadBuffer(buffer *MemObject, blocking bool, offse
 
 // EnqueueReadBufferFloat32 ..
 func (q *CommandQueue) EnqueueReadBufferFloat32(buffer *MemObject, blocking bool, offset int, data []float32, eventWaitList []*Event) (*Event, error) {
-	dataPtr := unsafe.Pointer(&data[0])
-	dataSize := int(unsafe.Sizeof(data[0])) * len(data)
-	return q.EnqueueReadBuffer(buffer, blocking, offset, dataSize, dataPtr, eventWaitList)
+	return q.EnqueueReadBufferHostMem(buffer, blocking, offset, SliceF32(data), eventWaitList)
+}
+
+// EnqueueReadBufferHostMem enqueues a command to fill all of the HostMem from
+// a buffer object starting at offset bytes.
+func (q *CommandQueue) EnqueueReadBufferHostMem(buffer *MemObject, blocking bool, offset int, h HostMem, eventWaitList []*Event) (*Event, error) {
+	if err := checkHostMem(h); err != nil {
+		return nil, err
+	}
+	return q.EnqueueReadBuffer(buffer, blocking, offset, hostMemSize(h), h.Ptr(), eventWaitList)
 }
 
 // EnqueueNDRangeKernel enqueues a command to execute a kernel on a device.
@@ -0,0 +1,95 @@
+package cl
+
+/*
+#cgo CFLAGS: -I CL -w
+#cgo !darwin LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Sampler is the cl_sampler wrapping struct. A Sampler describes how a
+// kernel reads from an image (read_imagef, read_imagei, etc..).
+type Sampler struct {
+	clSampler C.cl_sampler
+}
+
+// SamplerInfo is the information reported by clGetSamplerInfo.
+type SamplerInfo struct {
+	ReferenceCount   int
+	NormalizedCoords bool
+	AddressingMode   AddressingMode
+	FilterMode       FilterMode
+}
+
+func releaseSampler(s *Sampler) {
+	if s.clSampler != nil {
+		C.clReleaseSampler(s.clSampler)
+		s.clSampler = nil
+	}
+}
+
+// CreateSampler creates a sampler object. If normalizedCoords is true the
+// image coordinates are normalized to the range [0.0, 1.0].
+func (ctx *Context) CreateSampler(normalizedCoords bool, addressingMode AddressingMode, filterMode FilterMode) (*Sampler, error) {
+	var err C.cl_int
+	clSampler := C.clCreateSampler(ctx.clContext, clBool(normalizedCoords), C.cl_addressing_mode(addressingMode), C.cl_filter_mode(filterMode), &err)
+	if err != C.CL_SUCCESS {
+		return nil, toError(err)
+	}
+	if clSampler == nil {
+		return nil, ErrUnknown
+	}
+	sampler := &Sampler{clSampler: clSampler}
+	runtime.SetFinalizer(sampler, releaseSampler)
+	return sampler, nil
+}
+
+// Release decrements the OpenCL atomic reference count of the underlying cl_sampler.
+func (s *Sampler) Release() {
+	releaseSampler(s)
+}
+
+func (s *Sampler) getInfoUint(param C.cl_sampler_info) (uint, error) {
+	var val C.cl_uint
+	if err := C.clGetSamplerInfo(s.clSampler, param, C.size_t(unsafe.Sizeof(val)), unsafe.Pointer(&val), nil); err != C.CL_SUCCESS {
+		return 0, toError(err)
+	}
+	return uint(val), nil
+}
+
+// GetSamplerInfo returns the reference count, normalized coords, addressing
+// mode and filter mode of the sampler.
+func (s *Sampler) GetSamplerInfo() (SamplerInfo, error) {
+	var info SamplerInfo
+	refCount, err := s.getInfoUint(C.CL_SAMPLER_REFERENCE_COUNT)
+	if err != nil {
+		return info, err
+	}
+	normalizedCoords, err := s.getInfoUint(C.CL_SAMPLER_NORMALIZED_COORDS)
+	if err != nil {
+		return info, err
+	}
+	addressingMode, err := s.getInfoUint(C.CL_SAMPLER_ADDRESSING_MODE)
+	if err != nil {
+		return info, err
+	}
+	filterMode, err := s.getInfoUint(C.CL_SAMPLER_FILTER_MODE)
+	if err != nil {
+		return info, err
+	}
+	info.ReferenceCount = int(refCount)
+	info.NormalizedCoords = normalizedCoords == C.CL_TRUE
+	info.AddressingMode = AddressingMode(addressingMode)
+	info.FilterMode = FilterMode(filterMode)
+	return info, nil
+}
@@ -0,0 +1,28 @@
+package cl
+
+import "testing"
+
+func TestCreateSamplerWorks(t *testing.T) {
+	_, _, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	sampler, err := context.CreateSampler(true, AddressingModeClampToEdge, FilterModeLinear)
+	if err != nil {
+		t.Fatalf("CreateSampler error %v", err)
+	}
+	defer sampler.Release()
+	info, err := sampler.GetSamplerInfo()
+	if err != nil {
+		t.Fatalf("GetSamplerInfo error %v", err)
+	}
+	if !info.NormalizedCoords {
+		t.Fatalf("GetSamplerInfo NormalizedCoords expected true got false")
+	}
+	if info.AddressingMode != AddressingModeClampToEdge {
+		t.Fatalf("GetSamplerInfo AddressingMode expected %s got %s", AddressingModeClampToEdge, info.AddressingMode)
+	}
+	if info.FilterMode != FilterModeLinear {
+		t.Fatalf("GetSamplerInfo FilterMode expected %s got %s", FilterModeLinear, info.FilterMode)
+	}
+}
@@ -0,0 +1,435 @@
+package cl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// ErrUnsupportedStructField is returned when a Go struct has a field with no
+// OpenCL C equivalent.
+type ErrUnsupportedStructField struct {
+	Struct string
+	Field  string
+	Type   reflect.Type
+}
+
+func (e ErrUnsupportedStructField) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("cl: unsupported struct type %v", e.Type)
+	}
+	return fmt.Sprintf("cl: unsupported type %v of field %s.%s", e.Type, e.Struct, e.Field)
+}
+
+// clType is the OpenCL C layout of a Go type.
+type clType struct {
+	name   string // C type name, or element type name for arrays
+	size   uintptr
+	align  uintptr
+	goSize uintptr     // size of the Go value, less than size for 3 element vectors
+	elem   *clType     // element type of arrays
+	length int         // length of arrays
+	st     *StructType // layout of structs
+}
+
+// structField is a field of a StructType.
+type structField struct {
+	name   string
+	index  int
+	offset uintptr
+	typ    *clType
+}
+
+// StructType is the OpenCL C layout of a Go struct, which makes structs
+// usable as kernel arguments (see Kernel.SetArgStruct) and as elements of a
+// StructBuffer.
+//
+// Fields are laid out in order using the OpenCL C alignment rules: scalars
+// and vectors are aligned to their size (3 element vectors to the size of 4
+// element vectors), arrays to their elements and structs to their most
+// aligned field. Go arrays of 2, 3, 4, 8 or 16 numbers are vectors, other
+// arrays are C arrays. Unexported fields are skipped. Fields can be tagged
+// with `cl:"name"` to rename them in C, `cl:"-"` to skip them or
+// `cl:",array"` to make a Go array like [4]float32 a C array.
+type StructType struct {
+	name   string
+	size   uintptr
+	align  uintptr
+	fields []structField
+}
+
+var structTypes sync.Map // reflect.Type -> *StructType
+
+// NewStructType returns the StructType of the struct or pointer to struct v.
+func NewStructType(v interface{}) (*StructType, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrUnsupportedStructField{Type: t}
+	}
+	return structTypeOf(t)
+}
+
+func structTypeOf(t reflect.Type) (*StructType, error) {
+	if st, ok := structTypes.Load(t); ok {
+		return st.(*StructType), nil
+	}
+	st := &StructType{name: t.Name(), align: 1}
+	if st.name == "" {
+		return nil, ErrUnsupportedStructField{Type: t}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("cl")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		typ, err := clTypeOf(field.Type, opts == "array")
+		if err != nil {
+			return nil, ErrUnsupportedStructField{Struct: st.name, Field: field.Name, Type: field.Type}
+		}
+		offset := alignUp(st.size, typ.align)
+		st.fields = append(st.fields, structField{name: name, index: i, offset: offset, typ: typ})
+		st.size = offset + typ.size
+		if typ.align > st.align {
+			st.align = typ.align
+		}
+	}
+	if len(st.fields) == 0 {
+		return nil, ErrUnsupportedStructField{Struct: st.name, Type: t}
+	}
+	st.size = alignUp(st.size, st.align)
+	structTypes.Store(t, st)
+	return st, nil
+}
+
+func clTypeOf(t reflect.Type, array bool) (*clType, error) {
+	switch {
+	case t == reflect.TypeOf(Half(0)):
+		return &clType{name: Float16.ClSrc(), size: size16, align: size16, goSize: size16}, nil
+	case t.Kind() == reflect.Struct:
+		st, err := structTypeOf(t)
+		if err != nil {
+			return nil, err
+		}
+		return &clType{name: st.name, size: st.size, align: st.align, goSize: t.Size(), st: st}, nil
+	case t.Kind() == reflect.Array:
+		if vt, ok := vectorTypeOf(t); ok && !array {
+			return &clType{name: vt.ClSrc(), size: vt.SizeofT(), align: vt.SizeofT(), goSize: t.Size()}, nil
+		}
+		if t.Len() == 0 {
+			return nil, ErrInvalidNumType
+		}
+		elem, err := clTypeOf(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return &clType{name: elem.name, size: elem.size * uintptr(t.Len()), align: elem.align, goSize: t.Size(), elem: elem, length: t.Len()}, nil
+	}
+	scalar, ok := reflectKindTypes[t.Kind()]
+	if !ok {
+		return nil, ErrInvalidNumType
+	}
+	return &clType{name: scalar.ClSrc(), size: scalar.SizeofT(), align: scalar.SizeofT(), goSize: t.Size()}, nil
+}
+
+func alignUp(n, align uintptr) uintptr {
+	return (n + align - 1) / align * align
+}
+
+// Name is the name of the Go struct type, which is also its OpenCL C name.
+func (st *StructType) Name() string {
+	return st.name
+}
+
+// SizeofT is the size of the struct in OpenCL C, including padding.
+func (st *StructType) SizeofT() uintptr {
+	return st.size
+}
+
+// Align is the alignment of the struct in OpenCL C.
+func (st *StructType) Align() uintptr {
+	return st.align
+}
+
+// ClSrc is the OpenCL C type name of the struct.
+func (st *StructType) ClSrc() string {
+	return st.name
+}
+
+// declaration returns the C declaration of a variable of type t.
+func (t *clType) declaration(name string) string {
+	dims := ""
+	for ; t.elem != nil; t = t.elem {
+		dims += fmt.Sprintf("[%d]", t.length)
+	}
+	return t.name + " " + name + dims
+}
+
+// Typedef is the OpenCL C typedef of the struct. The typedefs of nested
+// structs are not included, see StructClSrc.
+func (st *StructType) Typedef() string {
+	var b strings.Builder
+	b.WriteString("typedef struct {\n")
+	for _, f := range st.fields {
+		fmt.Fprintf(&b, "    %s;\n", f.typ.declaration(f.name))
+	}
+	fmt.Fprintf(&b, "} %s;\n", st.name)
+	return b.String()
+}
+
+// StructClSrc returns the OpenCL C typedefs of the structs or pointers to
+// structs in values and of every struct nested in them, each once and in an
+// order that compiles, for inclusion in program source.
+func StructClSrc(values ...interface{}) (string, error) {
+	var typedefs []string
+	seen := make(map[*StructType]bool)
+	var add func(st *StructType)
+	add = func(st *StructType) {
+		if seen[st] {
+			return
+		}
+		seen[st] = true
+		for _, f := range st.fields {
+			t := f.typ
+			for t.elem != nil {
+				t = t.elem
+			}
+			if t.st != nil {
+				add(t.st)
+			}
+		}
+		typedefs = append(typedefs, st.Typedef())
+	}
+	for _, v := range values {
+		st, err := NewStructType(v)
+		if err != nil {
+			return "", err
+		}
+		add(st)
+	}
+	return strings.Join(typedefs, "\n"), nil
+}
+
+// encode writes the addressable value v of type t to dst.
+func (t *clType) encode(dst []byte, v reflect.Value) {
+	switch {
+	case t.st != nil:
+		for _, f := range t.st.fields {
+			f.typ.encode(dst[f.offset:], v.Field(f.index))
+		}
+	case t.elem != nil:
+		for i := 0; i < t.length; i++ {
+			t.elem.encode(dst[uintptr(i)*t.elem.size:], v.Index(i))
+		}
+	default:
+		copy(dst[:t.goSize], unsafe.Slice((*byte)(v.Addr().UnsafePointer()), t.goSize))
+	}
+}
+
+// decode reads the addressable value v of type t from src.
+func (t *clType) decode(src []byte, v reflect.Value) {
+	switch {
+	case t.st != nil:
+		for _, f := range t.st.fields {
+			f.typ.decode(src[f.offset:], v.Field(f.index))
+		}
+	case t.elem != nil:
+		for i := 0; i < t.length; i++ {
+			t.elem.decode(src[uintptr(i)*t.elem.size:], v.Index(i))
+		}
+	default:
+		copy(unsafe.Slice((*byte)(v.Addr().UnsafePointer()), t.goSize), src[:t.goSize])
+	}
+}
+
+// Marshal returns the OpenCL C representation of v, which must be of the
+// struct type (or a pointer to it) st was created from. Padding is zeroed.
+func (st *StructType) Marshal(v interface{}) []byte {
+	data := make([]byte, st.size)
+	st.encode(data, reflect.ValueOf(v))
+	return data
+}
+
+func (st *StructType) encode(dst []byte, v reflect.Value) {
+	if v.Kind() != reflect.Ptr {
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	} else {
+		v = v.Elem()
+	}
+	t := clType{st: st}
+	t.encode(dst, v)
+}
+
+// Unmarshal sets the struct v points to from its OpenCL C representation.
+func (st *StructType) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || uintptr(len(data)) < st.size {
+		return ErrHostMemInvalidData
+	}
+	t := clType{st: st}
+	t.decode(data, rv.Elem())
+	return nil
+}
+
+// isStructArg reports whether arg is a struct kernel argument.
+func isStructArg(arg interface{}) bool {
+	return arg != nil && reflect.TypeOf(arg).Kind() == reflect.Struct
+}
+
+// StructBuffer is a buffer MemObject holding elements of the struct type T in
+// their OpenCL C layout. It has the methods of Buffer, with offsets and
+// lengths counted in elements. Elements are converted on the host, so data
+// passed to a non-blocking Write can be reused as soon as Write returns, and
+// data passed to a non-blocking Read is only set once the returned event has
+// completed. Non-blocking transfers need OpenCL 1.1.
+type StructBuffer[T any] struct {
+	ctx        *Context
+	memObject  *MemObject
+	length     int
+	structType *StructType
+}
+
+// NewStructBuffer creates a StructBuffer of length elements.
+func NewStructBuffer[T any](ctx *Context, flags MemFlag, length int) (*StructBuffer[T], error) {
+	var zero T
+	st, err := NewStructType(zero)
+	if err != nil {
+		return nil, err
+	}
+	memObject, err := ctx.CreateEmptyBuffer(flags, length*int(st.size))
+	if err != nil {
+		return nil, err
+	}
+	return &StructBuffer[T]{ctx: ctx, memObject: memObject, length: length, structType: st}, nil
+}
+
+// NewStructBufferFromSlice creates a StructBuffer the length of data. data is
+// converted to a new host buffer first, so with MemUseHostPtr in flags the
+// buffer is not backed by data itself.
+func NewStructBufferFromSlice[T any](ctx *Context, flags MemFlag, data []T) (*StructBuffer[T], error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	var zero T
+	st, err := NewStructType(zero)
+	if err != nil {
+		return nil, err
+	}
+	host := marshalStructs(st, data)
+	memObject, err := ctx.CreateBufferUnsafe(flags, len(host), unsafe.Pointer(&host[0]))
+	if err != nil {
+		return nil, err
+	}
+	return &StructBuffer[T]{ctx: ctx, memObject: memObject, length: len(data), structType: st}, nil
+}
+
+func marshalStructs[T any](st *StructType, data []T) []byte {
+	host := make([]byte, len(data)*int(st.size))
+	for i := range data {
+		st.encode(host[uintptr(i)*st.size:], reflect.ValueOf(&data[i]))
+	}
+	return host
+}
+
+func unmarshalStructs[T any](st *StructType, host []byte, data []T) {
+	for i := range data {
+		st.Unmarshal(host[uintptr(i)*st.size:], &data[i])
+	}
+}
+
+// MemObject is the underlying memory object.
+func (b *StructBuffer[T]) MemObject() *MemObject {
+	return b.memObject
+}
+
+// Release ..
+func (b *StructBuffer[T]) Release() {
+	b.memObject.Release()
+}
+
+// Len is the number of elements in the StructBuffer.
+func (b *StructBuffer[T]) Len() int {
+	return b.length
+}
+
+// NumType is the StructType of T.
+func (b *StructBuffer[T]) NumType() NumTyped {
+	return b.structType
+}
+
+// SizeofT is the size of an element in OpenCL C.
+func (b *StructBuffer[T]) SizeofT() uintptr {
+	return b.structType.size
+}
+
+// checkRange returns ErrBufferOutOfRange unless length elements starting at
+// offset are within the StructBuffer.
+func (b *StructBuffer[T]) checkRange(offset, length int) error {
+	if offset < 0 || length < 0 || offset+length > b.length {
+		return ErrBufferOutOfRange
+	}
+	return nil
+}
+
+// Write enqueues a command to write data to the StructBuffer starting at
+// element offset.
+func (b *StructBuffer[T]) Write(q *CommandQueue, blocking bool, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	if err := b.checkRange(offset, len(data)); err != nil {
+		return nil, err
+	}
+	host := marshalStructs(b.structType, data)
+	if !blocking {
+		return b.writeAsync(q, offset, host, eventWaitList)
+	}
+	return q.EnqueueWriteBuffer(b.memObject, true, offset*int(b.structType.size), len(host), unsafe.Pointer(&host[0]), eventWaitList)
+}
+
+// Read enqueues a command to read len(data) elements of the StructBuffer
+// starting at element offset into data.
+func (b *StructBuffer[T]) Read(q *CommandQueue, blocking bool, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	if len(data) == 0 {
+		return nil, ErrHostMemWasEmpty
+	}
+	if err := b.checkRange(offset, len(data)); err != nil {
+		return nil, err
+	}
+	if !blocking {
+		return b.readAsync(q, offset, data, eventWaitList)
+	}
+	host := make([]byte, len(data)*int(b.structType.size))
+	event, err := q.EnqueueReadBuffer(b.memObject, true, offset*int(b.structType.size), len(host), unsafe.Pointer(&host[0]), eventWaitList)
+	if err != nil {
+		return nil, err
+	}
+	unmarshalStructs(b.structType, host, data)
+	return event, nil
+}
+
+// SubBuffer creates a StructBuffer that is a view of length elements of the
+// StructBuffer starting at element offset. The byte offset must be aligned to
+// the MemBaseAddrAlign of the devices, see CreateSubBuffer.
+func (b *StructBuffer[T]) SubBuffer(flags MemFlag, offset, length int) (*StructBuffer[T], error) {
+	if err := b.checkRange(offset, length); err != nil {
+		return nil, err
+	}
+	size := int(b.structType.size)
+	memObject, err := b.memObject.CreateSubBuffer(flags, offset*size, length*size)
+	if err != nil {
+		return nil, err
+	}
+	return &StructBuffer[T]{ctx: b.ctx, memObject: memObject, length: length, structType: b.structType}, nil
+}
@@ -0,0 +1,17 @@
+// +build cl10
+
+package cl
+
+// OpenCL 1.0 has no event callbacks to finish non-blocking transfers with.
+func (b *StructBuffer[T]) writeAsync(q *CommandQueue, offset int, host []byte, eventWaitList []*Event) (*Event, error) {
+	return nil, ErrUnsupported
+}
+
+func (b *StructBuffer[T]) readAsync(q *CommandQueue, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	return nil, ErrUnsupported
+}
+
+// Fill is not supported by OpenCL 1.0
+func (b *StructBuffer[T]) Fill(q *CommandQueue, value T, offset, length int, eventWaitList []*Event) (*Event, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,83 @@
+// +build !cl10
+
+package cl
+
+import (
+	"bytes"
+	"runtime"
+	"unsafe"
+)
+
+// maxFillPatternSize is the largest pattern clEnqueueFillBuffer accepts.
+const maxFillPatternSize = 128
+
+// writeAsync enqueues a non-blocking write of host, which is kept pinned
+// until the write has completed.
+func (b *StructBuffer[T]) writeAsync(q *CommandQueue, offset int, host []byte, eventWaitList []*Event) (*Event, error) {
+	pinner := new(runtime.Pinner)
+	pinner.Pin(&host[0])
+	event, err := q.EnqueueWriteBuffer(b.memObject, false, offset*int(b.structType.size), len(host), unsafe.Pointer(&host[0]), eventWaitList)
+	if err != nil {
+		pinner.Unpin()
+		return nil, err
+	}
+	if err := event.OnStatus(CommmandExecStatusComplete, func(CommmandExecStatus) { pinner.Unpin() }); err != nil {
+		WaitForEvents([]*Event{event})
+		pinner.Unpin()
+	}
+	return event, nil
+}
+
+// readAsync enqueues a non-blocking read into a host buffer that is
+// converted into data once the read has completed. The returned user event
+// completes after the conversion, or with the read's error status.
+func (b *StructBuffer[T]) readAsync(q *CommandQueue, offset int, data []T, eventWaitList []*Event) (*Event, error) {
+	done, err := b.ctx.CreateUserEvent()
+	if err != nil {
+		return nil, err
+	}
+	host := make([]byte, len(data)*int(b.structType.size))
+	pinner := new(runtime.Pinner)
+	pinner.Pin(&host[0])
+	read, err := q.EnqueueReadBuffer(b.memObject, false, offset*int(b.structType.size), len(host), unsafe.Pointer(&host[0]), eventWaitList)
+	if err != nil {
+		pinner.Unpin()
+		failUserEvents([]*Event{done})
+		done.Release()
+		return nil, err
+	}
+	finish := func(status CommmandExecStatus) {
+		defer pinner.Unpin()
+		if status < 0 {
+			done.SetUserEventStatus(int(status))
+			return
+		}
+		unmarshalStructs(b.structType, host, data)
+		done.SetUserEventStatus(int(CommmandExecStatusComplete))
+	}
+	if err := read.OnStatus(CommmandExecStatusComplete, finish); err != nil {
+		WaitForEvents([]*Event{read})
+		status, _ := read.Status()
+		finish(status)
+	}
+	return done, nil
+}
+
+// Fill enqueues a command to set length elements of the StructBuffer starting
+// at element offset to value. Elements that are too large or not a power of
+// two in size for clEnqueueFillBuffer are written with a non-blocking Write
+// instead.
+func (b *StructBuffer[T]) Fill(q *CommandQueue, value T, offset, length int, eventWaitList []*Event) (*Event, error) {
+	if err := b.checkRange(offset, length); err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return nil, ErrInvalidValue
+	}
+	pattern := b.structType.Marshal(&value)
+	size := len(pattern)
+	if size > maxFillPatternSize || size&(size-1) != 0 {
+		return b.writeAsync(q, offset, bytes.Repeat(pattern, length), eventWaitList)
+	}
+	return q.EnqueueFillBuffer(b.memObject, unsafe.Pointer(&pattern[0]), size, offset*size, length*size, eventWaitList)
+}
@@ -0,0 +1,116 @@
+// +build !cl10
+
+package cl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStructBufferNonBlockingWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	buffer, err := NewStructBuffer[testInner](context, MemReadWrite, 2)
+	if err != nil {
+		t.Fatalf("NewStructBuffer error %v", err)
+	}
+	input := []testInner{{A: 1, B: 2}, {A: 3, B: 4}}
+	written, err := buffer.Write(queue, false, 0, input, nil)
+	if err != nil {
+		t.Fatalf("Write error %v", err)
+	}
+	// the data was converted, so changing it must not change what is written
+	input[0].A = 9
+	output := make([]testInner, 2)
+	read, err := buffer.Read(queue, false, 0, output, []*Event{written})
+	if err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if err := WaitForEvents([]*Event{read}); err != nil {
+		t.Fatalf("WaitForEvents error %v", err)
+	}
+	expected := []testInner{{A: 1, B: 2}, {A: 3, B: 4}}
+	if !reflect.DeepEqual(expected, output) {
+		t.Fatalf("Read expected %v got %v", expected, output)
+	}
+}
+
+func TestStructBufferSubBufferWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	// one element per MemBaseAddrAlign so the sub-buffer origin is aligned
+	stride := devices[0].MemBaseAddrAlign() / 8 / 16
+	input := make([]testInner, 2*stride)
+	for i := range input {
+		input[i] = testInner{A: uint8(i), B: float64(i)}
+	}
+	buffer, err := NewStructBufferFromSlice(context, MemReadWrite|MemCopyHostPtr, input)
+	if err != nil {
+		t.Fatalf("NewStructBufferFromSlice error %v", err)
+	}
+	sub, err := buffer.SubBuffer(MemReadWrite, stride, stride)
+	if err != nil {
+		t.Fatalf("SubBuffer error %v", err)
+	}
+	output := make([]testInner, stride)
+	if _, err := sub.Read(queue, true, 0, output, nil); err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if !reflect.DeepEqual(input[stride:], output) {
+		t.Fatalf("SubBuffer Read expected %v got %v", input[stride:], output)
+	}
+}
+
+func TestStructBufferFillWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	inners, err := NewStructBuffer[testInner](context, MemReadWrite, 4)
+	if err != nil {
+		t.Fatalf("NewStructBuffer error %v", err)
+	}
+	inner := testInner{A: 7, B: 1.5}
+	if _, err := inners.Fill(queue, inner, 1, 3, nil); err != nil {
+		t.Fatalf("Fill error %v", err)
+	}
+	innerOut := make([]testInner, 4)
+	if _, err := inners.Read(queue, true, 0, innerOut, nil); err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if expected := []testInner{{}, inner, inner, inner}; !reflect.DeepEqual(expected, innerOut) {
+		t.Fatalf("Fill expected %v got %v", expected, innerOut)
+	}
+	// testParticle is 112 bytes, too large a fill pattern
+	particles, err := NewStructBuffer[testParticle](context, MemReadWrite, 2)
+	if err != nil {
+		t.Fatalf("NewStructBuffer error %v", err)
+	}
+	particle := testParticle{ID: 3, Inner: inner}
+	if _, err := particles.Fill(queue, particle, 0, 2, nil); err != nil {
+		t.Fatalf("Fill error %v", err)
+	}
+	particleOut := make([]testParticle, 2)
+	if _, err := particles.Read(queue, true, 0, particleOut, nil); err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if particleOut[0].ID != 3 || particleOut[1].Inner != inner {
+		t.Fatalf("Fill expected %v got %v", particle, particleOut)
+	}
+}
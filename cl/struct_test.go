@@ -0,0 +1,160 @@
+package cl
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type testInner struct {
+	A uint8
+	B float64
+}
+
+type testParticle struct {
+	Position [4]float32
+	ID       int32
+	Flags    uint8
+	Velocity [3]float32 `cl:"velocity"`
+	Weights  [3]float32 `cl:"weights,array"`
+	Inner    testInner
+	Pairs    [2]testInner
+	Ignored  float32 `cl:"-"`
+	skipped  int
+}
+
+func TestStructTypeLayout(t *testing.T) {
+	st, err := NewStructType(testParticle{})
+	if err != nil {
+		t.Fatalf("NewStructType error %v", err)
+	}
+	offsets := map[string]uintptr{}
+	for _, f := range st.fields {
+		offsets[f.name] = f.offset
+	}
+	expected := map[string]uintptr{"Position": 0, "ID": 16, "Flags": 20, "velocity": 32, "weights": 48, "Inner": 64, "Pairs": 80}
+	if !reflect.DeepEqual(offsets, expected) {
+		t.Fatalf("StructType offsets expected %v got %v", expected, offsets)
+	}
+	if st.SizeofT() != 112 || st.Align() != 16 {
+		t.Fatalf("StructType expected size 112 align 16 got %d %d", st.SizeofT(), st.Align())
+	}
+	src, err := StructClSrc(&testParticle{}, testInner{})
+	if err != nil {
+		t.Fatalf("StructClSrc error %v", err)
+	}
+	expectedSrc := `typedef struct {
+    uchar A;
+    double B;
+} testInner;
+
+typedef struct {
+    float4 Position;
+    int ID;
+    uchar Flags;
+    float3 velocity;
+    float weights[3];
+    testInner Inner;
+    testInner Pairs[2];
+} testParticle;
+`
+	if src != expectedSrc {
+		t.Fatalf("StructClSrc expected\n%s\ngot\n%s", expectedSrc, src)
+	}
+}
+
+func TestStructTypeMarshal(t *testing.T) {
+	st, err := NewStructType(testParticle{})
+	if err != nil {
+		t.Fatalf("NewStructType error %v", err)
+	}
+	p := testParticle{
+		Position: [4]float32{1, 2, 3, 4},
+		ID:       -7,
+		Flags:    3,
+		Velocity: [3]float32{5, 6, 7},
+		Weights:  [3]float32{8, 9, 10},
+		Inner:    testInner{A: 11, B: 12},
+		Pairs:    [2]testInner{{A: 13, B: 14}, {A: 15, B: 16}},
+		Ignored:  17,
+	}
+	data := st.Marshal(p)
+	f32 := func(off int) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(data[off:])) }
+	f64 := func(off int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(data[off:])) }
+	if f32(12) != 4 || int32(binary.LittleEndian.Uint32(data[16:])) != -7 || data[20] != 3 || f32(40) != 7 || f32(44) != 0 || f32(56) != 10 {
+		t.Fatalf("Marshal wrote wrong scalars and vectors % x", data[:64])
+	}
+	if data[64] != 11 || f64(72) != 12 || data[96] != 15 || f64(104) != 16 {
+		t.Fatalf("Marshal wrote wrong nested structs % x", data[64:])
+	}
+	var back testParticle
+	if err := st.Unmarshal(data, &back); err != nil {
+		t.Fatalf("Unmarshal error %v", err)
+	}
+	p.Ignored = 0
+	if back != p {
+		t.Fatalf("Unmarshal expected %+v got %+v", p, back)
+	}
+}
+
+func TestStructTypeErrors(t *testing.T) {
+	type withInt struct{ N int }
+	if _, err := NewStructType(withInt{}); err == nil {
+		t.Fatalf("NewStructType of struct with int field did not fail")
+	} else if e, ok := err.(ErrUnsupportedStructField); !ok || e.Field != "N" {
+		t.Fatalf("NewStructType expected ErrUnsupportedStructField for N got %v", err)
+	}
+	if _, err := NewStructType(struct{ A float32 }{}); err == nil {
+		t.Fatalf("NewStructType of anonymous struct did not fail")
+	}
+	if _, err := NewStructType(3); err == nil {
+		t.Fatalf("NewStructType of int did not fail")
+	}
+}
+
+func TestStructBufferChecksElementRange(t *testing.T) {
+	st, err := NewStructType(testInner{})
+	if err != nil {
+		t.Fatalf("NewStructType error %v", err)
+	}
+	buffer := &StructBuffer[testInner]{length: 4, structType: st}
+	if _, err := buffer.Write(nil, true, 2, make([]testInner, 3), nil); err != ErrBufferOutOfRange {
+		t.Fatalf("Write past the end expected ErrBufferOutOfRange got %v", err)
+	}
+	if _, err := buffer.Read(nil, false, -1, make([]testInner, 1), nil); err != ErrBufferOutOfRange {
+		t.Fatalf("Read before the start expected ErrBufferOutOfRange got %v", err)
+	}
+	if _, err := buffer.SubBuffer(MemReadWrite, 4, 1); err != ErrBufferOutOfRange {
+		t.Fatalf("SubBuffer past the end expected ErrBufferOutOfRange got %v", err)
+	}
+	if _, err := buffer.Fill(nil, testInner{}, 3, 2, nil); err != ErrBufferOutOfRange && err != ErrUnsupported {
+		t.Fatalf("Fill past the end expected ErrBufferOutOfRange got %v", err)
+	}
+}
+
+func TestStructBufferWorks(t *testing.T) {
+	_, devices, context, err := computingCtx()
+	if err != nil {
+		t.Fatalf("computingCtx error %v", err)
+	}
+	queue, err := context.CreateCommandQueue(devices[0], 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue error %v", err)
+	}
+	input := []testInner{{A: 1, B: 2}, {A: 3, B: 4}}
+	buffer, err := NewStructBufferFromSlice(context, MemReadWrite|MemCopyHostPtr, input)
+	if err != nil {
+		t.Fatalf("NewStructBufferFromSlice error %v", err)
+	}
+	if buffer.Len() != 2 || buffer.SizeofT() != 16 {
+		t.Fatalf("NewStructBufferFromSlice expected 2 elements of 16 bytes got %d %d", buffer.Len(), buffer.SizeofT())
+	}
+	output := make([]testInner, 2)
+	if _, err := buffer.Read(queue, true, 0, output, nil); err != nil {
+		t.Fatalf("Read error %v", err)
+	}
+	if !reflect.DeepEqual(input, output) {
+		t.Fatalf("Read expected %v got %v", input, output)
+	}
+}
@@ -226,6 +226,14 @@ func (ct MemCacheType) String() string {
 	return fmt.Sprintf("Unknown(%x)", int(ct))
 }
 
+// ContextPropertyName is the name of a ContextProperty.
+type ContextPropertyName int
+
+// ContextPropertyName variants
+const (
+	ContextPlatform ContextPropertyName = C.CL_CONTEXT_PLATFORM
+)
+
 // MemFlag is the type for changing mutability and allocation of a MemObject
 // upon creation.
 type MemFlag int
@@ -240,6 +248,9 @@ const (
 	MemCopyHostPtr  MemFlag = C.CL_MEM_COPY_HOST_PTR
 )
 
+// memAccessFlags are the MemFlags that set how kernels may access a buffer.
+const memAccessFlags = MemReadWrite | MemWriteOnly | MemReadOnly
+
 // MemObjectType ..
 type MemObjectType int
 
@@ -369,6 +380,78 @@ func (f ImageFormat) toCl() C.cl_image_format {
 	return format
 }
 
+// AddressingMode specifies how out-of-range image coordinates are handled
+// when reading from an image with a Sampler.
+type AddressingMode int
+
+// AddressingMode variants
+const (
+	AddressingModeNone        AddressingMode = C.CL_ADDRESS_NONE
+	AddressingModeClampToEdge AddressingMode = C.CL_ADDRESS_CLAMP_TO_EDGE
+	AddressingModeClamp       AddressingMode = C.CL_ADDRESS_CLAMP
+	AddressingModeRepeat      AddressingMode = C.CL_ADDRESS_REPEAT
+)
+
+var addressingModeNameMap = map[AddressingMode]string{
+	AddressingModeNone:        "None",
+	AddressingModeClampToEdge: "ClampToEdge",
+	AddressingModeClamp:       "Clamp",
+	AddressingModeRepeat:      "Repeat",
+}
+
+func (am AddressingMode) String() string {
+	name := addressingModeNameMap[am]
+	if name == "" {
+		name = fmt.Sprintf("Unknown(%x)", int(am))
+	}
+	return name
+}
+
+// FilterMode specifies the type of filter applied when reading an image
+// with a Sampler.
+type FilterMode int
+
+// FilterMode variants
+const (
+	FilterModeNearest FilterMode = C.CL_FILTER_NEAREST
+	FilterModeLinear  FilterMode = C.CL_FILTER_LINEAR
+)
+
+func (fm FilterMode) String() string {
+	switch fm {
+	case FilterModeNearest:
+		return "Nearest"
+	case FilterModeLinear:
+		return "Linear"
+	}
+	return fmt.Sprintf("Unknown(%x)", int(fm))
+}
+
+// BuildStatus is the build, compile or link status of a Program for a device.
+type BuildStatus int
+
+// BuildStatus variants
+const (
+	BuildStatusSuccess    BuildStatus = C.CL_BUILD_SUCCESS
+	BuildStatusNone       BuildStatus = C.CL_BUILD_NONE
+	BuildStatusError      BuildStatus = C.CL_BUILD_ERROR
+	BuildStatusInProgress BuildStatus = C.CL_BUILD_IN_PROGRESS
+)
+
+func (bs BuildStatus) String() string {
+	switch bs {
+	case BuildStatusSuccess:
+		return "Success"
+	case BuildStatusNone:
+		return "None"
+	case BuildStatusError:
+		return "Error"
+	case BuildStatusInProgress:
+		return "InProgress"
+	}
+	return fmt.Sprintf("Unknown(%d)", int(bs))
+}
+
 // ProfilingInfo ..
 type ProfilingInfo int
 
@@ -402,6 +485,140 @@ const (
 	CommmandExecStatusQueued    CommmandExecStatus = C.CL_QUEUED
 )
 
+// String for CommmandExecStatus. A negative status is the error code the
+// command terminated with.
+func (s CommmandExecStatus) String() string {
+	switch s {
+	case CommmandExecStatusComplete:
+		return "Complete"
+	case CommmandExecStatusRunning:
+		return "Running"
+	case CommmandExecStatusSubmitted:
+		return "Submitted"
+	case CommmandExecStatusQueued:
+		return "Queued"
+	}
+	return fmt.Sprintf("Error(%d)", int(s))
+}
+
+// CommandType is the type of command an Event identifies.
+type CommandType int
+
+// CommandType variants
+const (
+	CommandTypeNDRangeKernel     CommandType = C.CL_COMMAND_NDRANGE_KERNEL
+	CommandTypeTask              CommandType = C.CL_COMMAND_TASK
+	CommandTypeNativeKernel      CommandType = C.CL_COMMAND_NATIVE_KERNEL
+	CommandTypeReadBuffer        CommandType = C.CL_COMMAND_READ_BUFFER
+	CommandTypeWriteBuffer       CommandType = C.CL_COMMAND_WRITE_BUFFER
+	CommandTypeCopyBuffer        CommandType = C.CL_COMMAND_COPY_BUFFER
+	CommandTypeReadImage         CommandType = C.CL_COMMAND_READ_IMAGE
+	CommandTypeWriteImage        CommandType = C.CL_COMMAND_WRITE_IMAGE
+	CommandTypeCopyImage         CommandType = C.CL_COMMAND_COPY_IMAGE
+	CommandTypeCopyImageToBuffer CommandType = C.CL_COMMAND_COPY_IMAGE_TO_BUFFER
+	CommandTypeCopyBufferToImage CommandType = C.CL_COMMAND_COPY_BUFFER_TO_IMAGE
+	CommandTypeMapBuffer         CommandType = C.CL_COMMAND_MAP_BUFFER
+	CommandTypeMapImage          CommandType = C.CL_COMMAND_MAP_IMAGE
+	CommandTypeUnmapMemObject    CommandType = C.CL_COMMAND_UNMAP_MEM_OBJECT
+	CommandTypeMarker            CommandType = C.CL_COMMAND_MARKER
+	CommandTypeAcquireGLObjects  CommandType = C.CL_COMMAND_ACQUIRE_GL_OBJECTS
+	CommandTypeReleaseGLObjects  CommandType = C.CL_COMMAND_RELEASE_GL_OBJECTS
+)
+
+var commandTypeNameMap = map[CommandType]string{
+	CommandTypeNDRangeKernel:     "NDRangeKernel",
+	CommandTypeTask:              "Task",
+	CommandTypeNativeKernel:      "NativeKernel",
+	CommandTypeReadBuffer:        "ReadBuffer",
+	CommandTypeWriteBuffer:       "WriteBuffer",
+	CommandTypeCopyBuffer:        "CopyBuffer",
+	CommandTypeReadImage:         "ReadImage",
+	CommandTypeWriteImage:        "WriteImage",
+	CommandTypeCopyImage:         "CopyImage",
+	CommandTypeCopyImageToBuffer: "CopyImageToBuffer",
+	CommandTypeCopyBufferToImage: "CopyBufferToImage",
+	CommandTypeMapBuffer:         "MapBuffer",
+	CommandTypeMapImage:          "MapImage",
+	CommandTypeUnmapMemObject:    "UnmapMemObject",
+	CommandTypeMarker:            "Marker",
+	CommandTypeAcquireGLObjects:  "AcquireGLObjects",
+	CommandTypeReleaseGLObjects:  "ReleaseGLObjects",
+}
+
+func (ct CommandType) String() string {
+	name := commandTypeNameMap[ct]
+	if name == "" {
+		name = fmt.Sprintf("Unknown(%x)", int(ct))
+	}
+	return name
+}
+
+// ProgramBinaryType is the kind of binary a Program holds for a device
+// (OpenCL 1.2).
+type ProgramBinaryType int
+
+var programBinaryTypeNameMap = map[ProgramBinaryType]string{}
+
+func (t ProgramBinaryType) String() string {
+	name := programBinaryTypeNameMap[t]
+	if name == "" {
+		name = fmt.Sprintf("Unknown(%x)", int(t))
+	}
+	return name
+}
+
+// KernelArgAddressQualifier is the address space of a kernel argument
+// (OpenCL 1.2).
+type KernelArgAddressQualifier int
+
+var kernelArgAddressQualifierNameMap = map[KernelArgAddressQualifier]string{}
+
+func (q KernelArgAddressQualifier) String() string {
+	name := kernelArgAddressQualifierNameMap[q]
+	if name == "" {
+		name = fmt.Sprintf("Unknown(%x)", int(q))
+	}
+	return name
+}
+
+// KernelArgAccessQualifier is the access qualifier of an image or pipe
+// kernel argument (OpenCL 1.2).
+type KernelArgAccessQualifier int
+
+var kernelArgAccessQualifierNameMap = map[KernelArgAccessQualifier]string{}
+
+func (q KernelArgAccessQualifier) String() string {
+	name := kernelArgAccessQualifierNameMap[q]
+	if name == "" {
+		name = fmt.Sprintf("Unknown(%x)", int(q))
+	}
+	return name
+}
+
+// KernelArgTypeQualifier is a bitfield of the type qualifiers of a kernel
+// argument (OpenCL 1.2).
+type KernelArgTypeQualifier int
+
+var kernelArgTypeQualifierNameMap = map[KernelArgTypeQualifier]string{}
+
+func (q KernelArgTypeQualifier) String() string {
+	if q == 0 {
+		return "None"
+	}
+	var parts []string
+	for bit := KernelArgTypeQualifier(1); bit <= q; bit <<= 1 {
+		if q&bit == 0 {
+			continue
+		}
+		name := kernelArgTypeQualifierNameMap[bit]
+		if name == "" {
+			name = fmt.Sprintf("Unknown(%x)", int(bit))
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, "|")
+}
+
 func clBool(b bool) C.cl_bool {
 	if b {
 		return C.CL_TRUE
@@ -417,6 +634,27 @@ func sizeT3(i3 [3]int) [3]C.size_t {
 	return val
 }
 
+// queryString returns the string result of a clGet*Info call, made through
+// query with the size of value and a size_ret pointer, without the NUL
+// terminator.
+func queryString(query func(size C.size_t, value unsafe.Pointer, sizeRet *C.size_t) C.cl_int) (string, error) {
+	var strLen C.size_t
+	if err := query(0, nil, &strLen); err != C.CL_SUCCESS {
+		return "", toError(err)
+	}
+	if strLen == 0 {
+		return "", nil
+	}
+	buffer := make([]byte, strLen)
+	if err := query(strLen, unsafe.Pointer(&buffer[0]), nil); err != C.CL_SUCCESS {
+		return "", toError(err)
+	}
+	if buffer[len(buffer)-1] == 0 {
+		buffer = buffer[:len(buffer)-1]
+	}
+	return string(buffer), nil
+}
+
 // MappedMemObject ..
 type MappedMemObject struct {
 	ptr        unsafe.Pointer
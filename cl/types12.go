@@ -11,6 +11,10 @@ package cl
 #else
 #include <CL/cl.h>
 #endif
+
+#ifndef CL_KERNEL_ARG_TYPE_PIPE
+#define CL_KERNEL_ARG_TYPE_PIPE (1 << 3)
+#endif
 */
 import "C"
 
@@ -33,6 +37,36 @@ const (
 	// guarantee that the pointer returned by clEnqueueMapBuffer or clEnqueueMapImage contains the
 	// latest bits in the region being mapped which can be a significant performance enhancement.
 	MapFlagWriteInvalidateRegion MapFlag = C.CL_MAP_WRITE_INVALIDATE_REGION
+	// AddressingModeMirroredRepeat flips the image coordinate at every
+	// integer junction. It can only be used with normalized coordinates.
+	AddressingModeMirroredRepeat AddressingMode = C.CL_ADDRESS_MIRRORED_REPEAT
+	// ContextInteropUserSync specifies whether the user is responsible for
+	// synchronization between OpenCL and other APIs (Value 1 for true, 0 for false).
+	ContextInteropUserSync          ContextPropertyName       = C.CL_CONTEXT_INTEROP_USER_SYNC
+	CommandTypeReadBufferRect       CommandType               = C.CL_COMMAND_READ_BUFFER_RECT
+	CommandTypeWriteBufferRect      CommandType               = C.CL_COMMAND_WRITE_BUFFER_RECT
+	CommandTypeCopyBufferRect       CommandType               = C.CL_COMMAND_COPY_BUFFER_RECT
+	CommandTypeUser                 CommandType               = C.CL_COMMAND_USER
+	CommandTypeBarrier              CommandType               = C.CL_COMMAND_BARRIER
+	CommandTypeMigrateMemObjects    CommandType               = C.CL_COMMAND_MIGRATE_MEM_OBJECTS
+	CommandTypeFillBuffer           CommandType               = C.CL_COMMAND_FILL_BUFFER
+	CommandTypeFillImage            CommandType               = C.CL_COMMAND_FILL_IMAGE
+	ProgramBinaryTypeNone           ProgramBinaryType         = C.CL_PROGRAM_BINARY_TYPE_NONE
+	ProgramBinaryTypeCompiledObject ProgramBinaryType         = C.CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT
+	ProgramBinaryTypeLibrary        ProgramBinaryType         = C.CL_PROGRAM_BINARY_TYPE_LIBRARY
+	ProgramBinaryTypeExecutable     ProgramBinaryType         = C.CL_PROGRAM_BINARY_TYPE_EXECUTABLE
+	KernelArgAddressGlobal          KernelArgAddressQualifier = C.CL_KERNEL_ARG_ADDRESS_GLOBAL
+	KernelArgAddressLocal           KernelArgAddressQualifier = C.CL_KERNEL_ARG_ADDRESS_LOCAL
+	KernelArgAddressConstant        KernelArgAddressQualifier = C.CL_KERNEL_ARG_ADDRESS_CONSTANT
+	KernelArgAddressPrivate         KernelArgAddressQualifier = C.CL_KERNEL_ARG_ADDRESS_PRIVATE
+	KernelArgAccessReadOnly         KernelArgAccessQualifier  = C.CL_KERNEL_ARG_ACCESS_READ_ONLY
+	KernelArgAccessWriteOnly        KernelArgAccessQualifier  = C.CL_KERNEL_ARG_ACCESS_WRITE_ONLY
+	KernelArgAccessReadWrite        KernelArgAccessQualifier  = C.CL_KERNEL_ARG_ACCESS_READ_WRITE
+	KernelArgAccessNone             KernelArgAccessQualifier  = C.CL_KERNEL_ARG_ACCESS_NONE
+	KernelArgTypeConst              KernelArgTypeQualifier    = C.CL_KERNEL_ARG_TYPE_CONST
+	KernelArgTypeRestrict           KernelArgTypeQualifier    = C.CL_KERNEL_ARG_TYPE_RESTRICT
+	KernelArgTypeVolatile           KernelArgTypeQualifier    = C.CL_KERNEL_ARG_TYPE_VOLATILE
+	KernelArgTypePipe               KernelArgTypeQualifier    = C.CL_KERNEL_ARG_TYPE_PIPE // OpenCL 2.0
 )
 
 func init() {
@@ -48,6 +82,31 @@ func init() {
 	channelOrderNameMap[ChannelOrderDepth] = "Depth"
 	channelOrderNameMap[ChannelOrderDepthStencil] = "DepthStencil"
 	channelDataTypeNameMap[ChannelDataTypeUNormInt24] = "UNormInt24"
+	addressingModeNameMap[AddressingModeMirroredRepeat] = "MirroredRepeat"
+	commandTypeNameMap[CommandTypeReadBufferRect] = "ReadBufferRect"
+	commandTypeNameMap[CommandTypeWriteBufferRect] = "WriteBufferRect"
+	commandTypeNameMap[CommandTypeCopyBufferRect] = "CopyBufferRect"
+	commandTypeNameMap[CommandTypeUser] = "User"
+	commandTypeNameMap[CommandTypeBarrier] = "Barrier"
+	commandTypeNameMap[CommandTypeMigrateMemObjects] = "MigrateMemObjects"
+	commandTypeNameMap[CommandTypeFillBuffer] = "FillBuffer"
+	commandTypeNameMap[CommandTypeFillImage] = "FillImage"
+	programBinaryTypeNameMap[ProgramBinaryTypeNone] = "None"
+	programBinaryTypeNameMap[ProgramBinaryTypeCompiledObject] = "CompiledObject"
+	programBinaryTypeNameMap[ProgramBinaryTypeLibrary] = "Library"
+	programBinaryTypeNameMap[ProgramBinaryTypeExecutable] = "Executable"
+	kernelArgAddressQualifierNameMap[KernelArgAddressGlobal] = "Global"
+	kernelArgAddressQualifierNameMap[KernelArgAddressLocal] = "Local"
+	kernelArgAddressQualifierNameMap[KernelArgAddressConstant] = "Constant"
+	kernelArgAddressQualifierNameMap[KernelArgAddressPrivate] = "Private"
+	kernelArgAccessQualifierNameMap[KernelArgAccessReadOnly] = "ReadOnly"
+	kernelArgAccessQualifierNameMap[KernelArgAccessWriteOnly] = "WriteOnly"
+	kernelArgAccessQualifierNameMap[KernelArgAccessReadWrite] = "ReadWrite"
+	kernelArgAccessQualifierNameMap[KernelArgAccessNone] = "None"
+	kernelArgTypeQualifierNameMap[KernelArgTypeConst] = "Const"
+	kernelArgTypeQualifierNameMap[KernelArgTypeRestrict] = "Restrict"
+	kernelArgTypeQualifierNameMap[KernelArgTypeVolatile] = "Volatile"
+	kernelArgTypeQualifierNameMap[KernelArgTypePipe] = "Pipe"
 }
 
 // ImageDescription ..
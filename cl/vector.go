@@ -0,0 +1,252 @@
+package cl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Vector widths are stored above the scalar kind in a PrimitiveType.
+const vectorWidthShift = 8
+
+// PrimitiveType vector variants. OpenCL has no size_t vectors, so there are
+// none for Uint.
+const (
+	Int8x2  = Int8 | 2<<vectorWidthShift
+	Int8x3  = Int8 | 3<<vectorWidthShift
+	Int8x4  = Int8 | 4<<vectorWidthShift
+	Int8x8  = Int8 | 8<<vectorWidthShift
+	Int8x16 = Int8 | 16<<vectorWidthShift
+
+	Uint8x2  = Uint8 | 2<<vectorWidthShift
+	Uint8x3  = Uint8 | 3<<vectorWidthShift
+	Uint8x4  = Uint8 | 4<<vectorWidthShift
+	Uint8x8  = Uint8 | 8<<vectorWidthShift
+	Uint8x16 = Uint8 | 16<<vectorWidthShift
+
+	Int16x2  = Int16 | 2<<vectorWidthShift
+	Int16x3  = Int16 | 3<<vectorWidthShift
+	Int16x4  = Int16 | 4<<vectorWidthShift
+	Int16x8  = Int16 | 8<<vectorWidthShift
+	Int16x16 = Int16 | 16<<vectorWidthShift
+
+	Uint16x2  = Uint16 | 2<<vectorWidthShift
+	Uint16x3  = Uint16 | 3<<vectorWidthShift
+	Uint16x4  = Uint16 | 4<<vectorWidthShift
+	Uint16x8  = Uint16 | 8<<vectorWidthShift
+	Uint16x16 = Uint16 | 16<<vectorWidthShift
+
+	Int32x2  = Int32 | 2<<vectorWidthShift
+	Int32x3  = Int32 | 3<<vectorWidthShift
+	Int32x4  = Int32 | 4<<vectorWidthShift
+	Int32x8  = Int32 | 8<<vectorWidthShift
+	Int32x16 = Int32 | 16<<vectorWidthShift
+
+	Uint32x2  = Uint32 | 2<<vectorWidthShift
+	Uint32x3  = Uint32 | 3<<vectorWidthShift
+	Uint32x4  = Uint32 | 4<<vectorWidthShift
+	Uint32x8  = Uint32 | 8<<vectorWidthShift
+	Uint32x16 = Uint32 | 16<<vectorWidthShift
+
+	Float32x2  = Float32 | 2<<vectorWidthShift
+	Float32x3  = Float32 | 3<<vectorWidthShift
+	Float32x4  = Float32 | 4<<vectorWidthShift
+	Float32x8  = Float32 | 8<<vectorWidthShift
+	Float32x16 = Float32 | 16<<vectorWidthShift
+
+	Int64x2  = Int64 | 2<<vectorWidthShift
+	Int64x3  = Int64 | 3<<vectorWidthShift
+	Int64x4  = Int64 | 4<<vectorWidthShift
+	Int64x8  = Int64 | 8<<vectorWidthShift
+	Int64x16 = Int64 | 16<<vectorWidthShift
+
+	Uint64x2  = Uint64 | 2<<vectorWidthShift
+	Uint64x3  = Uint64 | 3<<vectorWidthShift
+	Uint64x4  = Uint64 | 4<<vectorWidthShift
+	Uint64x8  = Uint64 | 8<<vectorWidthShift
+	Uint64x16 = Uint64 | 16<<vectorWidthShift
+
+	Float64x2  = Float64 | 2<<vectorWidthShift
+	Float64x3  = Float64 | 3<<vectorWidthShift
+	Float64x4  = Float64 | 4<<vectorWidthShift
+	Float64x8  = Float64 | 8<<vectorWidthShift
+	Float64x16 = Float64 | 16<<vectorWidthShift
+
+	Float16x2  = Float16 | 2<<vectorWidthShift
+	Float16x3  = Float16 | 3<<vectorWidthShift
+	Float16x4  = Float16 | 4<<vectorWidthShift
+	Float16x8  = Float16 | 8<<vectorWidthShift
+	Float16x16 = Float16 | 16<<vectorWidthShift
+)
+
+// VectorType returns the PrimitiveType of a vector of width scalars. Width
+// must be one of 2, 3, 4, 8 or 16, or 1 for the scalar itself.
+func VectorType(scalar PrimitiveType, width int) (PrimitiveType, error) {
+	if scalar < Int8 || scalar > Float16 || (scalar == Uint && width != 1) {
+		return 0, ErrInvalidNumType
+	}
+	switch width {
+	case 1:
+		return scalar, nil
+	case 2, 3, 4, 8, 16:
+		return scalar | PrimitiveType(width)<<vectorWidthShift, nil
+	default:
+		return 0, ErrInvalidNumType
+	}
+}
+
+// Scalar is the element type of a vector type, or t itself for scalars.
+func (t PrimitiveType) Scalar() PrimitiveType {
+	return t & (1<<vectorWidthShift - 1)
+}
+
+// Width is the number of elements of a vector type, or 1 for scalars.
+func (t PrimitiveType) Width() int {
+	if w := int(t >> vectorWidthShift); w > 1 {
+		return w
+	}
+	return 1
+}
+
+// clScalarTypeNames maps OpenCL C scalar type names to PrimitiveTypes.
+var clScalarTypeNames = map[string]PrimitiveType{
+	"char":           Int8,
+	"signed char":    Int8,
+	"uchar":          Uint8,
+	"unsigned char":  Uint8,
+	"short":          Int16,
+	"ushort":         Uint16,
+	"unsigned short": Uint16,
+	"int":            Int32,
+	"uint":           Uint32,
+	"unsigned int":   Uint32,
+	"unsigned":       Uint32,
+	"float":          Float32,
+	"long":           Int64,
+	"ulong":          Uint64,
+	"unsigned long":  Uint64,
+	"double":         Float64,
+	"size_t":         Uint,
+	"half":           Float16,
+}
+
+// ParsePrimitiveType returns the PrimitiveType of an OpenCL C type name such
+// as "int", "unsigned char" or "float4".
+func ParsePrimitiveType(name string) (PrimitiveType, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if t, ok := clScalarTypeNames[name]; ok {
+		return t, nil
+	}
+	digits := len(name)
+	for digits > 0 && name[digits-1] >= '0' && name[digits-1] <= '9' {
+		digits--
+	}
+	scalar, ok := clScalarTypeNames[name[:digits]]
+	if !ok || digits == len(name) || strings.Contains(name, " ") {
+		return 0, fmt.Errorf("cl: unknown OpenCL type %q: %w", name, ErrInvalidNumType)
+	}
+	width, _ := strconv.Atoi(name[digits:])
+	t, err := VectorType(scalar, width)
+	if err != nil || width == 1 || strconv.Itoa(width) != name[digits:] {
+		return 0, fmt.Errorf("cl: unknown OpenCL type %q: %w", name, ErrInvalidNumType)
+	}
+	return t, nil
+}
+
+// reflectKindTypes maps the kinds of Go vector array elements to PrimitiveTypes.
+var reflectKindTypes = map[reflect.Kind]PrimitiveType{
+	reflect.Int8:    Int8,
+	reflect.Uint8:   Uint8,
+	reflect.Int16:   Int16,
+	reflect.Uint16:  Uint16,
+	reflect.Int32:   Int32,
+	reflect.Uint32:  Uint32,
+	reflect.Float32: Float32,
+	reflect.Int64:   Int64,
+	reflect.Uint64:  Uint64,
+	reflect.Float64: Float64,
+}
+
+// vectorTypeOf returns the vector PrimitiveType of a Go array type like [4]float32.
+func vectorTypeOf(t reflect.Type) (PrimitiveType, bool) {
+	if t.Kind() != reflect.Array {
+		return 0, false
+	}
+	scalar, ok := reflectKindTypes[t.Elem().Kind()]
+	if t.Elem() == reflect.TypeOf(Half(0)) {
+		scalar = Float16
+	}
+	if !ok || t.Len() == 1 {
+		return 0, false
+	}
+	vt, err := VectorType(scalar, t.Len())
+	return vt, err == nil
+}
+
+// vectorArgBytes returns the bytes of a Go array vector argument, padded to
+// the size of the OpenCL vector type (a float3 takes the space of a float4).
+func vectorArgBytes(arg interface{}) ([]byte, bool) {
+	v := reflect.ValueOf(arg)
+	if !v.IsValid() {
+		return nil, false
+	}
+	vt, ok := vectorTypeOf(v.Type())
+	if !ok {
+		return nil, false
+	}
+	copied := reflect.New(v.Type())
+	copied.Elem().Set(v)
+	data := make([]byte, vt.SizeofT())
+	copy(data, unsafe.Slice((*byte)(copied.UnsafePointer()), v.Type().Size()))
+	return data, true
+}
+
+// SliceVector is the HostMem of a slice of Go array vectors like [][4]float32.
+// Slices of 3 element arrays are not supported since OpenCL pads those
+// vectors to 4 elements; use 4 element arrays instead.
+type SliceVector struct {
+	data    interface{}
+	ptr     unsafe.Pointer
+	length  int
+	numType PrimitiveType
+}
+
+// newSliceVector returns the SliceVector of data if it is a slice of Go
+// array vectors. The slice may be empty, in which case Ptr is nil.
+func newSliceVector(data interface{}) (*SliceVector, bool) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	vt, ok := vectorTypeOf(v.Type().Elem())
+	if !ok || vt.Width() == 3 {
+		return nil, false
+	}
+	h := &SliceVector{data: data, length: v.Len(), numType: vt}
+	if h.length > 0 {
+		h.ptr = v.Index(0).Addr().UnsafePointer()
+	}
+	return h, true
+}
+
+// Ptr ..
+func (h *SliceVector) Ptr() unsafe.Pointer {
+	return h.ptr
+}
+
+// Len ..
+func (h *SliceVector) Len() int {
+	return h.length
+}
+
+// NumType ..
+func (h *SliceVector) NumType() NumTyped {
+	return h.numType
+}
+
+// SizeofT ..
+func (h *SliceVector) SizeofT() uintptr {
+	return h.numType.SizeofT()
+}